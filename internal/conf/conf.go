@@ -2,20 +2,92 @@ package conf
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type ConfigProvider interface {
+	Keys() []string
+	All() (map[string]string, error)
+	Has(key string) bool
+	HasErr(key string) (bool, error)
+	LookupString(key string) (string, bool)
+	LookupBool(key string) (bool, bool)
+	LookupFloat(key string) (float64, bool)
 	GetString(key string) (string, error)
 	GetFloat(key string) (float64, error)
 	GetBool(key string) (bool, error)
+	GetInt(key string) (int, error)
+	GetInt64(key string) (int64, error)
+	GetUint(key string) (uint, error)
+	GetDuration(key string) (time.Duration, error)
+	GetTime(key string) (time.Time, error)
+	GetStringSlice(key string) ([]string, error)
+	GetIntSlice(key string) ([]int, error)
+	GetFloatSlice(key string) ([]float64, error)
+	GetURL(key string, schemes ...string) (*url.URL, error)
+	GetBytes(key string, encoding ...Encoding) ([]byte, error)
+	GetIP(key string) (net.IP, error)
+	GetCIDR(key string) (*net.IPNet, error)
+	GetRegexp(key string) (*regexp.Regexp, error)
+	GetSize(key string) (int64, error)
+	GetPort(key string) (int, error)
+	GetLogLevel(key string) (slog.Level, error)
+	GetEnum(key string, allowed ...string) (string, error)
+	GetStruct(key string, out any) error
+	GetLocation(key string) (*time.Location, error)
+	GetHostPort(key string) (string, int, error)
+	GetHostPortDefault(key string, defaultPort int) (string, int, error)
+}
+
+// extendedGetters are the getters for less common types. Every provider
+// of the package implements them but they are not part of ConfigProvider
+// so other implementations do not have to
+type extendedGetters interface {
+	GetRateLimit(key string) (RateLimit, error)
+	GetUUID(key string) (uuid.UUID, error)
+	GetTemplate(key string, funcs ...template.FuncMap) (*template.Template, error)
+	GetMailAddress(key string) (*mail.Address, error)
+	GetMailAddressList(key string) ([]*mail.Address, error)
+	GetPath(key string) (string, error)
+	GetCron(key string) (*CronSchedule, error)
+	GetSemver(key string) (Semver, error)
+	GetSemverConstraint(key string) (*SemverConstraint, error)
+}
+
+// extended returns the extended getters of provider or ones parsing the
+// values of its GetString if it does not implement them
+func extended(provider ConfigProvider) extendedGetters {
+	if e, ok := provider.(extendedGetters); ok {
+		return e
+	}
+
+	return newTypedGetters(provider.GetString, nil)
 }
 
+// Sentinel errors matching the error types of the package with errors.Is
+var (
+	ErrKeyNotFound    = errors.New("key not found")
+	ErrTypeConversion = errors.New("type conversion failed")
+	ErrParsing        = errors.New("parsing failed")
+)
+
 type KeyNotFoundError struct {
 	Key     string
 	message string
@@ -28,14 +100,28 @@ func NewKeyNotFoundError(key string) *KeyNotFoundError {
 	}
 }
 
+// newKeyNotFoundInError is a KeyNotFoundError that names the sources
+// which were consulted
+func newKeyNotFoundInError(key string, sources []string) *KeyNotFoundError {
+	e := NewKeyNotFoundError(key)
+	e.message += fmt.Sprintf(" in [%s]", strings.Join(sources, ", "))
+
+	return e
+}
+
 func (e *KeyNotFoundError) Error() string {
 	return e.message
 }
 
+func (e *KeyNotFoundError) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
 type TypeConversionError struct {
 	Key     string
 	Value   string
 	Type    string
+	err     error
 	message string
 }
 
@@ -48,13 +134,33 @@ func NewTypeConversionError(key, value, typ string) *TypeConversionError {
 	}
 }
 
+func newTypeConversionErrorWithCause(key, value, typ string, err error) *TypeConversionError {
+	e := NewTypeConversionError(key, value, typ)
+	e.err = err
+	e.message += ": " + err.Error()
+
+	return e
+}
+
 func (e *TypeConversionError) Error() string {
 	return e.message
 }
 
+func (e *TypeConversionError) Unwrap() error {
+	return e.err
+}
+
+func (e *TypeConversionError) Is(target error) bool {
+	return target == ErrTypeConversion
+}
+
+// ParsingError indicates a malformed line. LineNumber is 1-based and 0
+// if the position of the line is unknown
 type ParsingError struct {
-	Line    string
-	message string
+	Line       string
+	LineNumber int
+	err        error
+	message    string
 }
 
 func NewParsingError(line string) *ParsingError {
@@ -64,10 +170,65 @@ func NewParsingError(line string) *ParsingError {
 	}
 }
 
+func NewParsingErrorAt(lineNumber int, line string) *ParsingError {
+	return &ParsingError{
+		Line:       line,
+		LineNumber: lineNumber,
+		message:    fmt.Sprintf("could not parse line %d: '%s'", lineNumber, line),
+	}
+}
+
+// newKeyCollisionError reports that line defines key which only differs
+// in case from an earlier key while keys are case-insensitive
+func newKeyCollisionError(lineNumber int, line, key, earlier string) *ParsingError {
+	e := NewParsingErrorAt(lineNumber, line)
+	if lineNumber == 0 {
+		e = NewParsingError(line)
+	}
+	e.message += fmt.Sprintf(": key '%s' collides with '%s' when ignoring case", key, earlier)
+
+	return e
+}
+
 func (e *ParsingError) Error() string {
 	return e.message
 }
 
+func (e *ParsingError) Unwrap() error {
+	return e.err
+}
+
+func (e *ParsingError) Is(target error) bool {
+	return target == ErrParsing
+}
+
+// ParsingErrors collects the errors of all malformed lines when parsing
+// with WithAllParsingErrors
+type ParsingErrors struct {
+	Errors  []error
+	message string
+}
+
+func NewParsingErrors(errs []error) *ParsingErrors {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return &ParsingErrors{
+		Errors:  errs,
+		message: fmt.Sprintf("%d lines could not be parsed: %s", len(errs), strings.Join(messages, "; ")),
+	}
+}
+
+func (e *ParsingErrors) Error() string {
+	return e.message
+}
+
+func (e *ParsingErrors) Unwrap() []error {
+	return e.Errors
+}
+
 type UnknownError struct {
 	err     error
 	message string
@@ -81,150 +242,1022 @@ func (e *UnknownError) Unwrap() error {
 	return e.err
 }
 
+// ChainError is returned by a ChainConfigProvider if a provider found the
+// key but could not deliver a valid value
+type ChainError struct {
+	Key     string
+	Errors  []error
+	message string
+}
+
+func NewChainError(key string, errs []error) *ChainError {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return &ChainError{
+		Key:     key,
+		Errors:  errs,
+		message: fmt.Sprintf("config value with key '%s' could not be resolved: %s", key, strings.Join(messages, "; ")),
+	}
+}
+
+// newNamedChainError is a ChainError whose message names the providers
+// consulted before the failing one and prefixes err with the name of the
+// provider it came from. Only err is part of the unwrapped Errors
+func newNamedChainError(key string, missed []string, name string, err error) *ChainError {
+	messages := make([]string, 0, len(missed)+1)
+	for _, missedName := range missed {
+		messages = append(messages, missedName+": "+NewKeyNotFoundError(key).Error())
+	}
+	messages = append(messages, name+": "+err.Error())
+
+	return &ChainError{
+		Key:     key,
+		Errors:  []error{err},
+		message: fmt.Sprintf("config value with key '%s' could not be resolved: %s", key, strings.Join(messages, "; ")),
+	}
+}
+
+func (e *ChainError) Error() string {
+	return e.message
+}
+
+func (e *ChainError) Unwrap() []error {
+	return e.Errors
+}
+
+// InvalidEnumError indicates that a value is not one of the allowed ones
+type InvalidEnumError struct {
+	Key     string
+	Value   string
+	Allowed []string
+	message string
+}
+
+func NewInvalidEnumError(key, value string, allowed []string) *InvalidEnumError {
+	return &InvalidEnumError{
+		Key:     key,
+		Value:   value,
+		Allowed: allowed,
+		message: fmt.Sprintf("value '%s' of key '%s' is not one of %q", value, key, allowed),
+	}
+}
+
+func (e *InvalidEnumError) Error() string {
+	return e.message
+}
+
+// PathNotFoundError indicates that the path a key resolves to does not
+// exist while WithExistingPaths is set
+type PathNotFoundError struct {
+	Key     string
+	Path    string
+	err     error
+	message string
+}
+
+func NewPathNotFoundError(key, path string, err error) *PathNotFoundError {
+	return &PathNotFoundError{
+		Key:     key,
+		Path:    path,
+		err:     err,
+		message: fmt.Sprintf("path '%s' of key '%s' does not exist", path, key),
+	}
+}
+
+func (e *PathNotFoundError) Error() string {
+	return e.message
+}
+
+func (e *PathNotFoundError) Unwrap() error {
+	return e.err
+}
+
 type FileConfigProvider struct {
-	path  string
-	store map[string]string
+	typedGetters
+	path     string
+	optional bool
+	store    map[string]string
+	mutex    sync.RWMutex
+
+	// changes holds the values of Set that have not been saved yet
+	changes map[string]string
+
+	callbackMutex        sync.Mutex
+	reloadCallbacks      []func(old, new map[string]string)
+	reloadErrorCallbacks []func(err error)
+}
+
+// NewFileConfigProvider creates a new FileConfigProvider for the file
+// at the given path.
+//
+// Deprecated behaviour: relative paths are resolved against the
+// directory of the calling source file which only works if the binary
+// runs on the machine it was built on. Use an absolute path or
+// NewFileConfigProviderRelative instead
+func NewFileConfigProvider(path string, opts ...Option) *FileConfigProvider {
+	return newFileConfigProvider(callerRelativePath(path), false, opts)
 }
 
-func NewFileConfigProvider(path string) *FileConfigProvider {
-	_, execPath, _, _ := runtime.Caller(1)
+// NewFileConfigProviderRelative creates a new FileConfigProvider for the
+// file at the given path relative to the current working directory
+func NewFileConfigProviderRelative(path string, opts ...Option) *FileConfigProvider {
+	return newFileConfigProvider(workingDirRelativePath(path), false, opts)
+}
+
+// NewOptionalFileConfigProvider creates a FileConfigProvider that treats
+// a missing file like an empty one instead of returning an error. Paths
+// are resolved like in NewFileConfigProvider
+func NewOptionalFileConfigProvider(path string, opts ...Option) *FileConfigProvider {
+	return newFileConfigProvider(callerRelativePath(path), true, opts)
+}
+
+// NewFileConfigProviderWithOptions creates a new FileConfigProvider for
+// the file at the given path like NewFileConfigProvider. It is meant to
+// be used with the parser options WithDelimiter, WithCommentPrefix and
+// WithTrimSpace to read '.properties', '.env' or '.conf' variants
+func NewFileConfigProviderWithOptions(path string, opts ...Option) *FileConfigProvider {
+	return newFileConfigProvider(callerRelativePath(path), false, opts)
+}
+
+func newFileConfigProvider(path string, optional bool, opts []Option) *FileConfigProvider {
+	cp := &FileConfigProvider{
+		path:     path,
+		optional: optional,
+	}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+	cp.typedGetters.baseDir = filepath.Dir(path)
+	cp.typedGetters.lookupString = cp.lookupString
+
+	return cp
+}
+
+// callerRelativePath resolves relative paths against the directory of
+// the source file that called the exported constructor. Absolute paths
+// are returned as they are
+func callerRelativePath(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	_, execPath, _, _ := runtime.Caller(2)
 	execDir := filepath.Dir(execPath)
-	realPath := filepath.Join(execDir, path)
 
-	return &FileConfigProvider{
-		path: realPath,
+	return filepath.Join(execDir, path)
+}
+
+// workingDirRelativePath resolves relative paths against the current
+// working directory at the time of the call
+func workingDirRelativePath(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return filepath.Clean(path)
 	}
+
+	return filepath.Join(workingDir, path)
 }
 
 func (cp *FileConfigProvider) GetString(key string) (string, error) {
-	if cp.store == nil {
-		m, err := initMapFromFile(cp.path)
-		if err != nil {
-			return "", err
-		}
-		cp.store = m
+	store, err := cp.loadStore()
+	if err != nil {
+		return "", err
 	}
 
-	value, ok := cp.store[key]
+	return lookupProperty(store, key, cp.options)
+}
+
+// GetScoped joins the given key segments with '.' and looks up the
+// result, so GetScoped("server", "http", "port") is the same as
+// GetString("server.http.port")
+func (cp *FileConfigProvider) GetScoped(keys ...string) (string, error) {
+	return cp.GetString(strings.Join(keys, "."))
+}
+
+// lookupProperty returns the value of key in a store of parsed
+// properties and expands it if interpolation is enabled
+func lookupProperty(store map[string]string, key string, o options) (string, error) {
+	value, ok := store[o.normalizeKey(key)]
 	if !ok {
 		return "", NewKeyNotFoundError(key)
 	}
 
+	if o.interpolation {
+		return interpolate(store, key, value, o)
+	}
+
 	return value, nil
 }
 
-func (cp *FileConfigProvider) GetFloat(key string) (float64, error) {
-	stringValue, err := cp.GetString(key)
+// Keys returns the sorted keys of the file or nil if it cannot be read
+func (cp *FileConfigProvider) Keys() []string {
+	store, err := cp.loadStore()
 	if err != nil {
-		return 0, err
+		return nil
 	}
 
-	value, err := strconv.ParseFloat(stringValue, 64)
+	return sortedKeys(store)
+}
+
+// All returns a copy of all values of the file
+func (cp *FileConfigProvider) All() (map[string]string, error) {
+	store, err := cp.loadStore()
 	if err != nil {
-		return value, NewTypeConversionError(key, stringValue, "float64")
+		return nil, err
 	}
 
-	return value, nil
+	return allProperties(store, cp.options)
 }
 
-func (cp *FileConfigProvider) GetBool(key string) (bool, error) {
-	stringValue, err := cp.GetString(key)
-	if err != nil {
-		return false, err
+// allProperties returns a copy of a store of parsed properties with all
+// values expanded like lookupProperty does
+func allProperties(store map[string]string, o options) (map[string]string, error) {
+	result := make(map[string]string, len(store))
+	for key := range store {
+		value, err := lookupProperty(store, key, o)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// loadStore lazily initializes the store exactly once and is safe for
+// concurrent use
+func (cp *FileConfigProvider) loadStore() (map[string]string, error) {
+	cp.mutex.RLock()
+	store := cp.store
+	cp.mutex.RUnlock()
+	if store != nil {
+		return store, nil
 	}
-	value, err := strconv.ParseBool(stringValue)
+
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	if cp.store == nil {
+		m, err := initMapFromFile(cp.path, cp.optional, cp.options)
+		if err != nil {
+			return nil, err
+		}
+		cp.store = applyChanges(m, cp.changes)
+	}
+
+	return cp.store, nil
+}
+
+// Reload re-reads the file and atomically replaces the current values. If
+// the file cannot be read or parsed the current values are kept. Values
+// that were Set but not saved yet are kept as well
+func (cp *FileConfigProvider) Reload() error {
+	m, err := initMapFromFile(cp.path, cp.optional, cp.options)
 	if err != nil {
-		return value, NewTypeConversionError(key, stringValue, "bool")
+		return err
 	}
 
-	return value, nil
+	cp.mutex.Lock()
+	old := cp.store
+	m = applyChanges(m, cp.changes)
+	cp.store = m
+	cp.mutex.Unlock()
+
+	cp.reloaded(old, m)
+
+	return nil
 }
 
-func initMapFromFile(path string) (map[string]string, error) {
-	store := map[string]string{}
+func sortedKeys(store map[string]string) []string {
+	keys := make([]string, 0, len(store))
+	for key := range store {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func initMapFromFile(path string, optional bool, o options) (map[string]string, error) {
 	file, err := os.Open(path)
+	if optional && os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
 	if err != nil {
-		return store, nil
+		return nil, &UnknownError{
+			err:     err,
+			message: fmt.Sprintf("could not open file with path '%s'", path),
+		}
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
+	store, err := parseProperties(file, o)
+	if err != nil && !isParsingError(err) {
+		err = &UnknownError{
+			err:     err,
+			message: fmt.Sprintf("could not read from file with path '%s'", path),
+		}
+	}
+
+	return store, err
+}
+
+// parseProperties parses 'key=value' lines read from r (the delimiter
+// can be changed with WithDelimiter). Malformed lines
+// result in a ParsingError, read errors are returned unchanged
+func parseProperties(r io.Reader, o options) (map[string]string, error) {
+	store := map[string]string{}
+	errs := []error{}
+	originalKeys := map[string]string{}
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
 		line := scanner.Text()
-		tokens := strings.Split(line, "=")
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if isBlankOrComment(line, o) {
+			continue
+		}
+		if name, ok := sectionHeader(line); ok {
+			section = name
+			continue
+		}
+
+		tokens := strings.SplitN(line, string(o.delimiter), 2)
 		if len(tokens) != 2 {
-			return nil, NewParsingError(line)
+			if !o.allParsingErrors {
+				return nil, NewParsingErrorAt(lineNumber, line)
+			}
+			errs = append(errs, NewParsingErrorAt(lineNumber, line))
+			continue
 		}
 
-		store[tokens[0]] = tokens[1]
+		key := joinKey(section, strings.TrimSpace(tokens[0]))
+		if o.caseInsensitiveKeys {
+			normalized := strings.ToLower(key)
+			if earlier, ok := originalKeys[normalized]; ok && earlier != key {
+				err := newKeyCollisionError(lineNumber, line, key, earlier)
+				if !o.allParsingErrors {
+					return nil, err
+				}
+				errs = append(errs, err)
+				continue
+			}
+			originalKeys[normalized] = key
+			key = normalized
+		}
+
+		store[key] = parseValue(tokens[1], o)
 	}
 
 	if err := scanner.Err(); err != nil {
-		err = &UnknownError{
-			err:     err,
-			message: fmt.Sprintf("could not read from file with path '%s'", path),
-		}
 		return nil, err
 	}
+	if len(errs) > 0 {
+		return nil, NewParsingErrors(errs)
+	}
 
 	return store, nil
 }
 
+func isBlankOrComment(line string, o options) bool {
+	trimmedLine := strings.TrimSpace(line)
+	return trimmedLine == "" || o.isCommentStart(trimmedLine)
+}
+
+// parseValue removes the surrounding whitespace (unless disabled with
+// WithTrimSpace) and inline comments from a raw value. Values wrapped in
+// double or single quotes are returned verbatim without the quotes
+func parseValue(raw string, o options) string {
+	trimmedValue := strings.TrimSpace(raw)
+	if len(trimmedValue) >= 2 && (trimmedValue[0] == '"' || trimmedValue[0] == '\'') {
+		quote := trimmedValue[0]
+		if end := strings.IndexByte(trimmedValue[1:], quote) + 1; end > 0 {
+			rest := strings.TrimSpace(trimmedValue[end+1:])
+			if rest == "" || (o.inlineComments && o.isCommentStart(rest)) {
+				return trimmedValue[1:end]
+			}
+		}
+	}
+
+	if o.inlineComments {
+		raw = stripInlineComment(raw, o)
+	}
+	if !o.trimSpace {
+		return raw
+	}
+
+	return strings.TrimSpace(raw)
+}
+
+// stripInlineComment removes trailing comments that are separated from
+// the value by whitespace (e.g. 'port=8080 # HTTP only') unless they are
+// part of a double quoted section
+func stripInlineComment(value string, o options) string {
+	// The whitespace after the delimiter does not separate a comment so
+	// 'channel = #general' keeps its value
+	start := len(value) - len(strings.TrimLeft(value, " \t"))
+	inQuotes := false
+	for i := start; i < len(value); i++ {
+		if value[i] == '"' {
+			inQuotes = !inQuotes
+		}
+		if !inQuotes && i > start && o.isCommentStart(value[i:]) && isSpace(value[i-1]) {
+			return value[:i]
+		}
+	}
+
+	return value
+}
+
+func isParsingError(err error) bool {
+	switch err.(type) {
+	case *ParsingError, *ParsingErrors:
+		return true
+	default:
+		return false
+	}
+}
+
+// sectionHeader returns the name of an INI style '[section]' header.
+// Keys following a header are prefixed with the section name, an empty
+// header '[]' returns to the root namespace
+func sectionHeader(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if len(line) < 2 || line[0] != '[' || line[len(line)-1] != ']' {
+		return "", false
+	}
+
+	return strings.TrimSpace(line[1 : len(line)-1]), true
+}
+
 type ChainConfigProvider struct {
+	// chain and names are replaced instead of modified in place so
+	// lookups can iterate over a snapshot without holding the mutex
+	mutex sync.RWMutex
 	chain []ConfigProvider
+	names []string
 }
 
 func NewChainConfigProvider(chain []ConfigProvider) *ChainConfigProvider {
-	return &ChainConfigProvider{chain}
+	return &ChainConfigProvider{chain: chain}
 }
 
-func (cp *ChainConfigProvider) GetString(key string) string {
-	var value string
-	var err error
-	for i := range cp.chain {
-		value, err = cp.chain[i].GetString(key)
-		if err == nil {
-			return value
+// NamedConfigProvider pairs a provider with a name (e.g. 'env' or
+// 'file:/etc/solvent.conf') that identifies it in errors
+type NamedConfigProvider struct {
+	Name     string
+	Provider ConfigProvider
+}
+
+// NewNamedChainConfigProvider creates a ChainConfigProvider that consults
+// the providers in the given order and names them in its errors
+func NewNamedChainConfigProvider(providers []NamedConfigProvider) *ChainConfigProvider {
+	cp := &ChainConfigProvider{
+		chain: make([]ConfigProvider, len(providers)),
+		names: make([]string, len(providers)),
+	}
+	for i, provider := range providers {
+		cp.chain[i] = provider.Provider
+		cp.names[i] = provider.Name
+	}
+
+	return cp
+}
+
+// Prepend adds p in front of the chain so its values take precedence
+// over the ones of all other providers
+func (cp *ChainConfigProvider) Prepend(p ConfigProvider) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	cp.chain = append([]ConfigProvider{p}, cp.chain...)
+	if cp.names != nil {
+		cp.names = append([]string{providerName(p)}, cp.names...)
+	}
+}
+
+// Append adds p to the end of the chain so it is only consulted if no
+// other provider has a key
+func (cp *ChainConfigProvider) Append(p ConfigProvider) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	cp.chain = append(cp.chain[:len(cp.chain):len(cp.chain)], p)
+	if cp.names != nil {
+		cp.names = append(cp.names[:len(cp.names):len(cp.names)], providerName(p))
+	}
+}
+
+// Remove removes the provider at the given index of the chain. It panics
+// if the index is out of range
+func (cp *ChainConfigProvider) Remove(index int) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	cp.chain = append(cp.chain[:index:index], cp.chain[index+1:]...)
+	if cp.names != nil {
+		cp.names = append(cp.names[:index:index], cp.names[index+1:]...)
+	}
+}
+
+// providerOptions returns the options of the first provider that has
+// any as a chain has none of its own
+func (cp *ChainConfigProvider) providerOptions() (options, bool) {
+	chain, _ := cp.providers()
+	for _, provider := range chain {
+		if p, ok := provider.(optionsProvider); ok {
+			if o, ok := p.providerOptions(); ok {
+				return o, true
+			}
 		}
 	}
 
-	panic(err)
+	return options{}, false
 }
 
-func (cp *ChainConfigProvider) GetFloat(key string) float64 {
-	var value float64
-	var err error
-	for i := range cp.chain {
-		value, err = cp.chain[i].GetFloat(key)
-		if err == nil {
-			return value
+// providers returns a snapshot of the current providers and their names
+func (cp *ChainConfigProvider) providers() ([]ConfigProvider, []string) {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+
+	return cp.chain, cp.names
+}
+
+// providerName is the name of a provider added to a named chain after
+// its creation, e.g. '*conf.EnvConfigProvider'
+func providerName(p ConfigProvider) string {
+	return fmt.Sprintf("%T", p)
+}
+
+// Keys returns the sorted union of the keys of all providers
+func (cp *ChainConfigProvider) Keys() []string {
+	chain, _ := cp.providers()
+	keys := map[string]struct{}{}
+	for i := range chain {
+		for _, key := range chain[i].Keys() {
+			keys[key] = struct{}{}
 		}
 	}
 
-	panic(err)
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+
+	return result
 }
 
-func (cp *ChainConfigProvider) GetBool(key string) bool {
-	var value bool
-	var err error
-	for i := range cp.chain {
-		value, err = cp.chain[i].GetBool(key)
-		if err == nil {
-			return value
+// All merges the values of all providers. Values of earlier providers
+// take precedence over the ones of later providers
+func (cp *ChainConfigProvider) All() (map[string]string, error) {
+	chain, _ := cp.providers()
+	result := map[string]string{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		values, err := chain[i].All()
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			result[key] = value
 		}
 	}
 
-	panic(err)
+	return result, nil
+}
+
+// Has reports whether any provider of the chain has a value for the
+// given key
+func (cp *ChainConfigProvider) Has(key string) bool {
+	chain, _ := cp.providers()
+	for i := range chain {
+		if chain[i].Has(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasErr reports whether any provider of the chain has the key. It stops
+// with a ChainError at the first provider that fails with an error other
+// than a missing key
+func (cp *ChainConfigProvider) HasErr(key string) (bool, error) {
+	chain, names := cp.providers()
+	for i := range chain {
+		has, err := chain[i].HasErr(key)
+		if err != nil {
+			return false, chainError(key, names, i, err)
+		}
+		if has {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (cp *ChainConfigProvider) GetString(key string) (string, error) {
+	var value string
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetString(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetFloat(key string) (float64, error) {
+	var value float64
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetFloat(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetBool(key string) (bool, error) {
+	var value bool
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetBool(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetInt(key string) (int, error) {
+	var value int
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetInt(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetInt64(key string) (int64, error) {
+	var value int64
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetInt64(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetUint(key string) (uint, error) {
+	var value uint
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetUint(key)
+		return err
+	})
+
+	return value, err
 }
 
-func (cp *ChainConfigProvider) chainLookup(key string, f func(provider ConfigProvider) error) {
-	var err error
-	for i := range cp.chain {
-		err = f(cp.chain[i])
+func (cp *ChainConfigProvider) GetDuration(key string) (time.Duration, error) {
+	var value time.Duration
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetDuration(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetTime(key string) (time.Time, error) {
+	var value time.Time
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetTime(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetStringSlice(key string) ([]string, error) {
+	var value []string
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetStringSlice(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetIntSlice(key string) ([]int, error) {
+	var value []int
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetIntSlice(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetFloatSlice(key string) ([]float64, error) {
+	var value []float64
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetFloatSlice(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetURL(key string, schemes ...string) (*url.URL, error) {
+	var value *url.URL
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetURL(key, schemes...)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetBytes(key string, encoding ...Encoding) ([]byte, error) {
+	var value []byte
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetBytes(key, encoding...)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetIP(key string) (net.IP, error) {
+	var value net.IP
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetIP(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetCIDR(key string) (*net.IPNet, error) {
+	var value *net.IPNet
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetCIDR(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetRegexp(key string) (*regexp.Regexp, error) {
+	var value *regexp.Regexp
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetRegexp(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetSize(key string) (int64, error) {
+	var value int64
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetSize(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetRateLimit(key string) (RateLimit, error) {
+	var value RateLimit
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetRateLimit(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetPort(key string) (int, error) {
+	var value int
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetPort(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetLogLevel(key string) (slog.Level, error) {
+	var value slog.Level
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetLogLevel(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetEnum(key string, allowed ...string) (string, error) {
+	var value string
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetEnum(key, allowed...)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetStruct(key string, out any) error {
+	return cp.chainLookup(key, func(provider ConfigProvider) error {
+		return provider.GetStruct(key, out)
+	})
+}
+
+func (cp *ChainConfigProvider) GetLocation(key string) (*time.Location, error) {
+	var value *time.Location
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = provider.GetLocation(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetUUID(key string) (uuid.UUID, error) {
+	var value uuid.UUID
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetUUID(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetHostPort(key string) (string, int, error) {
+	var host string
+	var port int
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		host, port, err = provider.GetHostPort(key)
+		return err
+	})
+
+	return host, port, err
+}
+
+func (cp *ChainConfigProvider) GetHostPortDefault(key string, defaultPort int) (string, int, error) {
+	var host string
+	var port int
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		host, port, err = provider.GetHostPortDefault(key, defaultPort)
+		return err
+	})
+
+	return host, port, err
+}
+
+func (cp *ChainConfigProvider) GetTemplate(key string, funcs ...template.FuncMap) (*template.Template, error) {
+	var value *template.Template
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetTemplate(key, funcs...)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetMailAddress(key string) (*mail.Address, error) {
+	var value *mail.Address
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetMailAddress(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetMailAddressList(key string) ([]*mail.Address, error) {
+	var value []*mail.Address
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetMailAddressList(key)
+		return err
+	})
+
+	return value, err
+}
+
+// GetPath resolves relative paths against the directory of the file
+// based provider that supplied the value
+func (cp *ChainConfigProvider) GetPath(key string) (string, error) {
+	var value string
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetPath(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetCron(key string) (*CronSchedule, error) {
+	var value *CronSchedule
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetCron(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetSemver(key string) (Semver, error) {
+	var value Semver
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetSemver(key)
+		return err
+	})
+
+	return value, err
+}
+
+func (cp *ChainConfigProvider) GetSemverConstraint(key string) (*SemverConstraint, error) {
+	var value *SemverConstraint
+	err := cp.chainLookup(key, func(provider ConfigProvider) error {
+		var err error
+		value, err = extended(provider).GetSemverConstraint(key)
+		return err
+	})
+
+	return value, err
+}
+
+// chainLookup calls f with every provider of the chain until one of them
+// succeeds. Only a KeyNotFoundError continues with the next provider. If
+// the key is missing in every provider a KeyNotFoundError is returned,
+// otherwise a ChainError with the error of the failing provider. Misses
+// of earlier providers are left out so the ChainError does not unwrap to
+// a KeyNotFoundError
+func (cp *ChainConfigProvider) chainLookup(key string, f func(provider ConfigProvider) error) error {
+	chain, names := cp.providers()
+	for i := range chain {
+		err := f(chain[i])
 		if err == nil {
-			return
+			return nil
 		}
+
+		if !isKeyNotFound(err) {
+			return chainError(key, names, i, err)
+		}
+	}
+
+	if names != nil {
+		return newKeyNotFoundInError(key, names)
+	}
+
+	return NewKeyNotFoundError(key)
+}
+
+// chainError creates a ChainError for the error of the provider at the
+// given index after all earlier providers missed the key, naming them if
+// names are given
+func chainError(key string, names []string, failed int, err error) *ChainError {
+	if names != nil {
+		return newNamedChainError(key, names[:failed], names[failed], err)
 	}
 
-	panic(err)
+	return NewChainError(key, []error{err})
 }
@@ -0,0 +1,1916 @@
+package conf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+	"github.com/google/uuid"
+)
+
+const testFile = "testdata/test.properties"
+
+func TestGetString(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetString("string")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "value", value, "cp.GetString value")
+
+	_, err = cp.GetString("missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "cp.GetString error")
+}
+
+func TestGetInt(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		key      string
+		expected int
+	}{
+		{"int", 42},
+		{"int.negative", -42},
+		{"int.zero", 0},
+		{"int.leadingZero", 80},
+		{"int.padded", 8080},
+		{"int.hex", 8080},
+		{"int.octal", 15},
+		{"int.binary", -5},
+		{"int.underscore", 1000000},
+		{"int.hexUnderscore", 0xFF00},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetInt(test.key)
+		AssertEquals(t, nil, err, "cp.GetInt error")
+		AssertEquals(t, test.expected, value, "cp.GetInt value")
+	}
+
+	_, err := cp.GetInt("int.float")
+	AssertEquals(t, NewTypeConversionError("int.float", "8080.9", "int"), err, "cp.GetInt error")
+
+	_, err = cp.GetInt("int.decimalFloat")
+	AssertEquals(t, NewTypeConversionError("int.decimalFloat", "8080.0", "int"), err, "cp.GetInt error")
+
+	_, err = cp.GetInt("int.overflow")
+	AssertEquals(t, NewTypeConversionError("int.overflow", "99999999999999999999", "int"), err, "cp.GetInt error")
+
+	_, err = cp.GetInt("int.doubleUnderscore")
+	AssertEquals(t, NewTypeConversionError("int.doubleUnderscore", "1__000", "int"), err, "cp.GetInt error")
+}
+
+func TestGetIntStrictDecimal(t *testing.T) {
+	cp := NewFileConfigProvider(testFile, WithStrictDecimal())
+
+	value, err := cp.GetInt("int.leadingZero")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 80, value, "cp.GetInt value")
+
+	_, err = cp.GetInt("int.hex")
+	AssertEquals(t, NewTypeConversionError("int.hex", "0x1F90", "int"), err, "cp.GetInt error")
+
+	_, err = cp.GetInt64("int.underscore")
+	AssertEquals(t, NewTypeConversionError("int.underscore", "1_000_000", "int64"), err, "cp.GetInt64 error")
+
+	_, err = cp.GetUint("int.octal")
+	AssertEquals(t, NewTypeConversionError("int.octal", "0o17", "uint"), err, "cp.GetUint error")
+}
+
+func TestGetInt64(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetInt64("int.leadingZero")
+	AssertEquals(t, nil, err, "cp.GetInt64 error")
+	AssertEquals(t, int64(80), value, "cp.GetInt64 value")
+
+	value, err = cp.GetInt64("int64.large")
+	AssertEquals(t, nil, err, "cp.GetInt64 error")
+	AssertEquals(t, int64(9007199254740993), value, "cp.GetInt64 value")
+
+	_, err = cp.GetInt64("int.overflow")
+	AssertEquals(t, NewTypeConversionError("int.overflow", "99999999999999999999", "int64"), err, "cp.GetInt64 error")
+}
+
+func TestGetUint(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetUint("int.padded")
+	AssertEquals(t, nil, err, "cp.GetUint error")
+	AssertEquals(t, uint(8080), value, "cp.GetUint value")
+
+	value, err = cp.GetUint("int.hex")
+	AssertEquals(t, nil, err, "cp.GetUint error")
+	AssertEquals(t, uint(8080), value, "cp.GetUint value")
+
+	_, err = cp.GetUint("int.negative")
+	AssertEquals(t, NewTypeConversionError("int.negative", "-42", "uint"), err, "cp.GetUint error")
+}
+
+func TestChainGetInt(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	intValue, err := cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 42, intValue, "cp.GetInt value")
+
+	int64Value, err := cp.GetInt64("int.negative")
+	AssertEquals(t, nil, err, "cp.GetInt64 error")
+	AssertEquals(t, int64(-42), int64Value, "cp.GetInt64 value")
+
+	uintValue, err := cp.GetUint("int.zero")
+	AssertEquals(t, nil, err, "cp.GetUint error")
+	AssertEquals(t, uint(0), uintValue, "cp.GetUint value")
+}
+
+func TestChainGetStringMissing(t *testing.T) {
+	var cp ConfigProvider = NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	_, err := cp.GetString("missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "cp.GetString error")
+
+	_, err = NewChainConfigProvider(nil).GetString("missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "cp.GetString error")
+}
+
+func TestGetDuration(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		key      string
+		expected time.Duration
+	}{
+		{"duration", 2*time.Hour + 45*time.Minute},
+		{"duration.millis", 500 * time.Millisecond},
+		{"duration.seconds", 30 * time.Second},
+		{"duration.fractional", 1500 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetDuration(test.key)
+		AssertEquals(t, nil, err, "cp.GetDuration error")
+		AssertEquals(t, test.expected, value, "cp.GetDuration value")
+	}
+
+	_, err := cp.GetDuration("duration.invalid")
+	AssertEquals(t, NewTypeConversionError("duration.invalid", "30 seconds", "time.Duration"), err, "cp.GetDuration error")
+
+	_, err = cp.GetDuration("duration.nan")
+	AssertEquals(t, NewTypeConversionError("duration.nan", "NaN", "time.Duration"), err, "cp.GetDuration error")
+
+	overflows := NewInMemoryConfigProvider(map[string]string{
+		"int":      "9223372037",
+		"negative": "-9223372037",
+		"float":    "9223372036.9",
+		"huge":     "99999999999999999999",
+		"inf":      "Inf",
+	})
+	for _, key := range overflows.Keys() {
+		_, err = overflows.GetDuration(key)
+		value, _ := overflows.GetString(key)
+		AssertEquals(t, NewTypeConversionError(key, value, "time.Duration"), err, "overflows.GetDuration error")
+	}
+
+	limit := NewInMemoryConfigProvider(map[string]string{"max": "9223372036"})
+	value, err := limit.GetDuration("max")
+	AssertEquals(t, nil, err, "limit.GetDuration error")
+	AssertEquals(t, 9223372036*time.Second, value, "limit.GetDuration value")
+}
+
+func TestChainGetDuration(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_DURATION", "1m")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	value, err := cp.GetDuration("duration")
+	AssertEquals(t, nil, err, "cp.GetDuration error")
+	AssertEquals(t, time.Minute, value, "cp.GetDuration value")
+
+	value, err = cp.GetDuration("duration.seconds")
+	AssertEquals(t, nil, err, "cp.GetDuration error")
+	AssertEquals(t, 30*time.Second, value, "cp.GetDuration value")
+}
+
+func TestGetTime(t *testing.T) {
+	cp := NewFileConfigProvider(testFile, WithTimeLayouts("2006-01-02", time.Kitchen))
+
+	value, err := cp.GetTime("time")
+	AssertEquals(t, nil, err, "cp.GetTime error")
+	AssertEquals(t, "2026-03-01T10:30:00+02:00", value.Format(time.RFC3339), "cp.GetTime value")
+	_, offset := value.Zone()
+	AssertEquals(t, 2*60*60, offset, "cp.GetTime zone offset")
+
+	value, err = cp.GetTime("time.date")
+	AssertEquals(t, nil, err, "cp.GetTime error")
+	AssertEquals(t, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), value, "cp.GetTime value")
+
+	_, err = cp.GetTime("time.invalid")
+	expectedErr := newTypeConversionErrorWithCause("time.invalid", "tomorrow", "time.Time",
+		fmt.Errorf("tried layouts %q", []string{time.RFC3339, "2006-01-02", time.Kitchen}))
+	AssertEquals(t, expectedErr, err, "cp.GetTime error")
+	AssertEquals(t, `value 'tomorrow' of key 'time.invalid' cannot be converted to expected type 'time.Time': tried layouts ["2006-01-02T15:04:05Z07:00" "2006-01-02" "3:04PM"]`,
+		err.Error(), "cp.GetTime error message")
+}
+
+func TestGetTimeDefaultLayout(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	_, err := cp.GetTime("time.date")
+	AssertEquals(t, "time.Time", err.(*TypeConversionError).Type, "cp.GetTime error type")
+}
+
+func TestGetStringSlice(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		key      string
+		expected []string
+	}{
+		{"slice", []string{"https://a.com", "https://b.com", "https://c.com"}},
+		{"slice.single", []string{"a"}},
+		{"slice.empty", []string{}},
+		{"slice.quoted", []string{`"a`, `b"`, "c"}},
+		{"slice.trailing", []string{"a", "b"}},
+		{"slice.gaps", []string{"a", "b", "c"}},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetStringSlice(test.key)
+		AssertEquals(t, nil, err, "cp.GetStringSlice error")
+		AssertEquals(t, test.expected, value, "cp.GetStringSlice value")
+	}
+}
+
+func TestGetStringSliceWithOptions(t *testing.T) {
+	tests := []struct {
+		key      string
+		opts     []Option
+		expected []string
+	}{
+		{"slice.semicolon", []Option{WithSeparator(";")}, []string{"a", "b", "c"}},
+		{"slice.whitespace", []Option{WithSeparator(" ")}, []string{"a", "b", "c"}},
+		{"slice.quoted", []Option{WithQuotedElements()}, []string{"a,b", "c"}},
+		{"slice.empty", []Option{WithSeparator(" ")}, []string{}},
+		{"slice.gaps", []Option{WithSeparator(";")}, []string{"a,, b, ,c"}},
+		{"slice.quoted.empty", []Option{WithQuotedElements()}, []string{"a", "", "b"}},
+	}
+
+	for _, test := range tests {
+		cp := NewFileConfigProvider(testFile, test.opts...)
+		value, err := cp.GetStringSlice(test.key)
+		AssertEquals(t, nil, err, "cp.GetStringSlice error")
+		AssertEquals(t, test.expected, value, "cp.GetStringSlice value")
+	}
+}
+
+func TestChainGetStringSlice(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_SLICE", "x;y")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_", WithSeparator(";")),
+		NewFileConfigProvider(testFile),
+	})
+
+	value, err := cp.GetStringSlice("slice")
+	AssertEquals(t, nil, err, "cp.GetStringSlice error")
+	AssertEquals(t, []string{"x", "y"}, value, "cp.GetStringSlice value")
+
+	value, err = cp.GetStringSlice("slice.single")
+	AssertEquals(t, nil, err, "cp.GetStringSlice error")
+	AssertEquals(t, []string{"a"}, value, "cp.GetStringSlice value")
+}
+
+func TestChainError(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_INT", "forty-two")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	_, err := cp.GetInt("int")
+	AssertEquals(t, NewChainError("int", []error{
+		NewTypeConversionError("int", "forty-two", "int"),
+	}), err, "cp.GetInt error")
+
+	_, err = cp.GetBool("int")
+	expectedErr := NewChainError("int", []error{
+		NewTypeConversionError("int", "forty-two", "bool"),
+	})
+	AssertEquals(t, expectedErr, err, "cp.GetBool error")
+
+	var conversionErr *TypeConversionError
+	AssertEquals(t, true, errors.As(err, &conversionErr), "errors.As TypeConversionError")
+	AssertEquals(t, "forty-two", conversionErr.Value, "conversionErr.Value")
+
+	var notFoundErr *KeyNotFoundError
+	_, err = cp.GetBool("missing")
+	AssertEquals(t, true, errors.As(err, &notFoundErr), "errors.As KeyNotFoundError")
+}
+
+func TestGetIntSlice(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetIntSlice("slice.ints")
+	AssertEquals(t, nil, err, "cp.GetIntSlice error")
+	AssertEquals(t, []int{1, 2, 5, 13}, value, "cp.GetIntSlice value")
+
+	value, err = cp.GetIntSlice("slice.empty")
+	AssertEquals(t, nil, err, "cp.GetIntSlice error")
+	AssertEquals(t, []int{}, value, "cp.GetIntSlice value")
+
+	_, err = cp.GetIntSlice("slice.invalid")
+	expectedErr := newSliceElementError("slice.invalid", "1,2,x,4", "[]int", 2, "x")
+	AssertEquals(t, expectedErr, err, "cp.GetIntSlice error")
+	AssertEquals(t, "value '1,2,x,4' of key 'slice.invalid' cannot be converted to expected type '[]int': element 2 'x' is invalid",
+		err.Error(), "cp.GetIntSlice error message")
+}
+
+func TestGetFloatSlice(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetFloatSlice("slice.floats")
+	AssertEquals(t, nil, err, "cp.GetFloatSlice error")
+	AssertEquals(t, []float64{0.1, 0.7, 0.2}, value, "cp.GetFloatSlice value")
+
+	_, err = cp.GetFloatSlice("slice.invalid")
+	AssertEquals(t, newSliceElementError("slice.invalid", "1,2,x,4", "[]float64", 2, "x"), err, "cp.GetFloatSlice error")
+}
+
+func TestChainGetIntSlice(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_SLICE_INTS", "3 4")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_", WithSeparator(" ")),
+		NewFileConfigProvider(testFile),
+	})
+
+	value, err := cp.GetIntSlice("slice.ints")
+	AssertEquals(t, nil, err, "cp.GetIntSlice error")
+	AssertEquals(t, []int{3, 4}, value, "cp.GetIntSlice value")
+
+	floats, err := cp.GetFloatSlice("slice.floats")
+	AssertEquals(t, nil, err, "cp.GetFloatSlice error")
+	AssertEquals(t, []float64{0.1, 0.7, 0.2}, floats, "cp.GetFloatSlice value")
+}
+
+func TestHas(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	AssertEquals(t, true, cp.Has("string"), "cp.Has string")
+	AssertEquals(t, true, cp.Has("slice.empty"), "cp.Has slice.empty")
+	AssertEquals(t, false, cp.Has("missing"), "cp.Has missing")
+}
+
+func TestChainHas(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_ONLY_ENV", "1")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	AssertEquals(t, true, cp.Has("only.env"), "cp.Has only.env")
+	AssertEquals(t, true, cp.Has("string"), "cp.Has string")
+	AssertEquals(t, false, cp.Has("missing"), "cp.Has missing")
+}
+
+func TestGetURL(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetURL("url")
+	AssertEquals(t, nil, err, "cp.GetURL error")
+	AssertEquals(t, "example.com:8443", value.Host, "cp.GetURL host")
+
+	value, err = cp.GetURL("url", "https", "wss")
+	AssertEquals(t, nil, err, "cp.GetURL error")
+	AssertEquals(t, "https", value.Scheme, "cp.GetURL scheme")
+
+	_, err = cp.GetURL("url.ws", "https", "wss")
+	expectedErr := newTypeConversionErrorWithCause("url.ws", "ws://example.com/socket", "*url.URL",
+		fmt.Errorf("scheme 'ws' is not one of %q", []string{"https", "wss"}))
+	AssertEquals(t, expectedErr, err, "cp.GetURL error")
+
+	_, err = cp.GetURL("url.relative")
+	AssertEquals(t, newTypeConversionErrorWithCause("url.relative", "/api/v1", "*url.URL", errors.New("missing scheme")), err, "cp.GetURL error")
+
+	_, err = cp.GetURL("url.noHost")
+	AssertEquals(t, newTypeConversionErrorWithCause("url.noHost", "https://", "*url.URL", errors.New("missing host")), err, "cp.GetURL error")
+
+	_, err = cp.GetURL("url.hostPort")
+	AssertEquals(t, newTypeConversionErrorWithCause("url.hostPort", "localhost:8080", "*url.URL", errors.New("missing scheme")), err, "cp.GetURL error")
+
+	_, err = cp.GetURL("url.invalid")
+	AssertEquals(t, "*url.URL", err.(*TypeConversionError).Type, "cp.GetURL error type")
+}
+
+func TestGetURLRelative(t *testing.T) {
+	cp := NewFileConfigProvider(testFile, WithRelativeURLs())
+
+	value, err := cp.GetURL("url.relative")
+	AssertEquals(t, nil, err, "cp.GetURL error")
+	AssertEquals(t, "/api/v1", value.Path, "cp.GetURL path")
+}
+
+func TestConcurrentGetString(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cp.GetString("string")
+			AssertEquals(t, nil, err, "cp.GetString error")
+			AssertEquals(t, "value", value, "cp.GetString value")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestChainKeys(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewJSONConfigProviderFromReader(strings.NewReader(`{"b": 1, "a": {"c": 2}, "string": "x"}`)),
+		NewJSONConfigProviderFromReader(strings.NewReader(`{"string": "y", "d": true}`)),
+	})
+
+	AssertEquals(t, []string{"a.c", "b", "d", "string"}, cp.Keys(), "cp.Keys")
+}
+
+func TestChainKeysFileAndMemory(t *testing.T) {
+	fileKeys := NewFileConfigProvider(testFile).Keys()
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{"string": "override", "zzz.only.memory": "1"}),
+		NewFileConfigProvider(testFile),
+	})
+
+	keys := cp.Keys()
+	AssertEquals(t, len(fileKeys)+1, len(keys), "len(cp.Keys)")
+	AssertEquals(t, true, sort.StringsAreSorted(keys), "cp.Keys sorted")
+	AssertEquals(t, "zzz.only.memory", keys[len(keys)-1], "last key")
+	AssertEquals(t, true, cp.Has("zzz.only.memory"), "cp.Has zzz.only.memory")
+
+	empty := NewChainConfigProvider([]ConfigProvider{})
+	AssertEquals(t, []string{}, empty.Keys(), "empty.Keys")
+	AssertEquals(t, false, empty.Has("string"), "empty.Has string")
+}
+
+func TestChainPrependAppendRemove(t *testing.T) {
+	providers := []ConfigProvider{NewFileConfigProvider(testFile)}
+	cp := NewChainConfigProvider(providers)
+
+	cp.Prepend(NewInMemoryConfigProvider(map[string]string{"int": "7"}))
+	value, err := cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 7, value, "cp.GetInt value of prepended provider")
+
+	cp.Append(NewInMemoryConfigProvider(map[string]string{"int": "8", "only.appended": "1"}))
+	value, err = cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 7, value, "cp.GetInt value")
+	AssertEquals(t, true, cp.Has("only.appended"), "cp.Has only.appended")
+
+	cp.Remove(0)
+	value, err = cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 42, value, "cp.GetInt value after Remove")
+
+	cp.Remove(1)
+	AssertEquals(t, false, cp.Has("only.appended"), "cp.Has only.appended after Remove")
+	AssertEquals(t, 1, len(providers), "slice passed to constructor is not modified")
+}
+
+func TestNamedChainPrepend(t *testing.T) {
+	cp := NewNamedChainConfigProvider([]NamedConfigProvider{
+		{Name: "file", Provider: NewFileConfigProvider(testFile)},
+	})
+	cp.Prepend(NewInMemoryConfigProvider(nil))
+
+	_, err := cp.GetString("missing")
+	AssertEquals(t, newKeyNotFoundInError("missing", []string{"*conf.InMemoryConfigProvider", "file"}), err, "cp.GetString error")
+
+	cp.Remove(0)
+	_, err = cp.GetString("missing")
+	AssertEquals(t, newKeyNotFoundInError("missing", []string{"file"}), err, "cp.GetString error")
+}
+
+func TestChainConcurrentModification(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{NewFileConfigProvider(testFile)})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			cp.Append(NewInMemoryConfigProvider(map[string]string{"int": "8"}))
+			cp.Prepend(NewInMemoryConfigProvider(map[string]string{"other": "1"}))
+		}()
+		go func() {
+			defer wg.Done()
+			value, err := cp.GetInt("int")
+			AssertEquals(t, nil, err, "cp.GetInt error")
+			AssertEquals(t, 42, value, "cp.GetInt value")
+			cp.Keys()
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 20; i++ {
+		cp.Remove(0)
+	}
+	AssertEquals(t, false, cp.Has("other"), "cp.Has other")
+}
+
+func TestGetBytes(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		key      string
+		encoding []Encoding
+		expected []byte
+	}{
+		{"bytes.base64", nil, []byte("secret")},
+		{"bytes.base64url", nil, []byte{0xfb, 0xff, 0xbf}},
+		{"bytes.hex", nil, []byte{0x0a, 0x0b, 0x0c}},
+		{"bytes.hex", []Encoding{Hex}, []byte{0x0a, 0x0b, 0x0c}},
+		{"bytes.base64", []Encoding{Base64}, []byte("secret")},
+		{"bytes.invalid", []Encoding{Raw}, []byte("not base64!")},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetBytes(test.key, test.encoding...)
+		AssertEquals(t, nil, err, "cp.GetBytes error")
+		AssertEquals(t, test.expected, value, "cp.GetBytes value")
+	}
+
+	_, err := cp.GetBytes("bytes.invalid")
+	AssertEquals(t, NewTypeConversionError("bytes.invalid", "not base64!", "[]byte"), err, "cp.GetBytes error")
+
+	_, err = cp.GetBytes("bytes.base64url", Hex)
+	AssertEquals(t, NewTypeConversionError("bytes.base64url", "-_-_", "[]byte"), err, "cp.GetBytes error")
+}
+
+func TestGetBytesReturnsCopy(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, _ := cp.GetBytes("bytes.invalid", Raw)
+	value[0] = 'N'
+
+	value, _ = cp.GetBytes("bytes.invalid", Raw)
+	AssertEquals(t, []byte("not base64!"), value, "cp.GetBytes value")
+}
+
+func TestMissingFile(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/missing.properties")
+
+	_, err := cp.GetString("string")
+	var pathErr *os.PathError
+	AssertEquals(t, true, errors.As(err, &pathErr), "errors.As os.PathError")
+	AssertEquals(t, true, errors.Is(err, os.ErrNotExist), "errors.Is os.ErrNotExist")
+	AssertEquals(t, false, cp.Has("string"), "cp.Has string")
+}
+
+func TestOptionalMissingFile(t *testing.T) {
+	cp := NewOptionalFileConfigProvider("testdata/missing.properties")
+
+	_, err := cp.GetString("string")
+	AssertEquals(t, NewKeyNotFoundError("string"), err, "cp.GetString error")
+
+	cp = NewOptionalFileConfigProvider(testFile)
+
+	value, err := cp.GetString("string")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "value", value, "cp.GetString value")
+}
+
+func TestGetIP(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetIP("ip")
+	AssertEquals(t, nil, err, "cp.GetIP error")
+	AssertEquals(t, true, value.Equal(net.IPv4zero), "cp.GetIP value")
+
+	value, err = cp.GetIP("ip.v6")
+	AssertEquals(t, nil, err, "cp.GetIP error")
+	AssertEquals(t, true, value.Equal(net.IPv6loopback), "cp.GetIP value")
+
+	_, err = cp.GetIP("ip.invalid")
+	AssertEquals(t, NewTypeConversionError("ip.invalid", "256.0.0.1", "net.IP"), err, "cp.GetIP error")
+}
+
+func TestGetCIDR(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetCIDR("cidr")
+	AssertEquals(t, nil, err, "cp.GetCIDR error")
+	AssertEquals(t, "10.0.0.0/8", value.String(), "cp.GetCIDR value")
+
+	value, err = cp.GetCIDR("cidr.v6")
+	AssertEquals(t, nil, err, "cp.GetCIDR error")
+	AssertEquals(t, "2001:db8::/32", value.String(), "cp.GetCIDR value")
+
+	_, err = cp.GetCIDR("cidr.invalid")
+	AssertEquals(t, NewTypeConversionError("cidr.invalid", "10.0.0.0/33", "*net.IPNet"), err, "cp.GetCIDR error")
+}
+
+func TestComments(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/comments.properties")
+
+	expected := []string{"channel", "channel.commented", "postgres.host", "postgres.port", "postgres.user", "separator", "url"}
+	AssertEquals(t, expected, cp.Keys(), "cp.Keys")
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"channel", "#general"},
+		{"channel.commented", "#general"},
+		{"separator", ";"},
+		{"postgres.host", "localhost"},
+		{"postgres.port", "5432"},
+		{"postgres.user", "solvent"},
+		{"url", "https://example.com/#anchor"},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetString(test.key)
+		AssertEquals(t, nil, err, "cp.GetString error")
+		AssertEquals(t, test.expected, value, "cp.GetString value")
+	}
+}
+
+func TestWithoutInlineComments(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/comments.properties", WithoutInlineComments())
+
+	value, err := cp.GetString("postgres.port")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "5432 # default port", value, "cp.GetString value")
+}
+
+func TestAdjacentComments(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/adjacent-comments.properties")
+
+	AssertEquals(t, []string{"postgres.host", "postgres.port"}, cp.Keys(), "cp.Keys")
+
+	value, err := cp.GetString("postgres.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "db", value, "cp.GetString value")
+
+	value, err = cp.GetString("postgres.port")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "5432", value, "cp.GetString value")
+}
+
+func TestEqualsInValue(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetString("dsn")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "user=admin;pass=secret", value, "cp.GetString value")
+
+	value, err = cp.GetString("db_url")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "jdbc:mysql://host/db?param=val", value, "cp.GetString value")
+
+	value, err = cp.GetString("bytes.padded")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "c2VjcmV0IQ==", value, "cp.GetString value")
+
+	bytes, err := cp.GetBytes("bytes.padded")
+	AssertEquals(t, nil, err, "cp.GetBytes error")
+	AssertEquals(t, []byte("secret!"), bytes, "cp.GetBytes value")
+}
+
+func TestParsingError(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/invalid.properties")
+
+	_, err := cp.GetString("valid")
+	AssertEquals(t, NewParsingErrorAt(2, "invalid"), err, "cp.GetString error")
+}
+
+func TestGetRegexp(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetRegexp("regexp")
+	AssertEquals(t, nil, err, "cp.GetRegexp error")
+	AssertEquals(t, true, value.MatchString("/api/v2/items"), "cp.GetRegexp match")
+
+	cached, err := cp.GetRegexp("regexp")
+	AssertEquals(t, nil, err, "cp.GetRegexp error")
+	AssertEquals(t, true, value == cached, "cp.GetRegexp cached")
+
+	_, err = cp.GetRegexp("regexp.invalid")
+	var syntaxErr *syntax.Error
+	AssertEquals(t, true, errors.As(err, &syntaxErr), "errors.As syntax.Error")
+	AssertEquals(t, "*regexp.Regexp", err.(*TypeConversionError).Type, "cp.GetRegexp error type")
+}
+
+func TestGetRegexpPOSIX(t *testing.T) {
+	cp := NewFileConfigProvider(testFile, WithPOSIXRegexps())
+
+	value, err := cp.GetRegexp("regexp.alternation")
+	AssertEquals(t, nil, err, "cp.GetRegexp error")
+	AssertEquals(t, "ab", value.FindString("abc"), "cp.GetRegexp leftmost-longest match")
+}
+
+func TestGetSize(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		key      string
+		expected int64
+	}{
+		{"size", 64 << 20},
+		{"size.decimal", 10 * 1000 * 1000},
+		{"size.lower", 512 << 10},
+		{"size.spaced", 2 * 1000 * 1000 * 1000},
+		{"size.fractional", 1500 * 1000 * 1000},
+		{"size.rounded", 1025},
+		{"size.bytes", 2048},
+		{"size.unit", 3},
+		{"size.kib", 1024},
+		{"size.kb", 1000},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetSize(test.key)
+		AssertEquals(t, nil, err, "cp.GetSize error")
+		AssertEquals(t, test.expected, value, "cp.GetSize value")
+	}
+
+	for _, key := range []string{"size.unknown", "size.overflow", "size.negative"} {
+		stringValue, _ := cp.GetString(key)
+		_, err := cp.GetSize(key)
+		AssertEquals(t, NewTypeConversionError(key, stringValue, "size"), err, "cp.GetSize error")
+	}
+}
+
+func TestGetRateLimit(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected RateLimit
+	}{
+		{"100/s", RateLimit{Count: 100, Per: time.Second}},
+		{"500/m", RateLimit{Count: 500, Per: time.Minute}},
+		{" 1000 / h ", RateLimit{Count: 1000, Per: time.Hour}},
+		{"100/s burst=20", RateLimit{Count: 100, Per: time.Second, Burst: 20}},
+		{"100/s  burst = 20", RateLimit{Count: 100, Per: time.Second, Burst: 20}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			cp := NewInMemoryConfigProvider(map[string]string{"api.limit": test.value})
+			value, err := cp.GetRateLimit("api.limit")
+			AssertEquals(t, nil, err, "cp.GetRateLimit error")
+			AssertEquals(t, test.expected, value, "cp.GetRateLimit value")
+		})
+	}
+}
+
+func TestGetRateLimitInvalid(t *testing.T) {
+	cause := errors.New("expected format 'N/unit' with unit s, m or h and an optional 'burst=N'")
+	for _, value := range []string{"/s", "100/fortnight", "100", "0/s", "-5/s", "100/", "100/s burst", "100/s burst=0", "100/s burst=many", "100/s 20"} {
+		t.Run(value, func(t *testing.T) {
+			cp := NewInMemoryConfigProvider(map[string]string{"api.limit": value})
+			_, err := cp.GetRateLimit("api.limit")
+			AssertEquals(t, newTypeConversionErrorWithCause("api.limit", value, "rate limit", cause).Error(), err.Error(), "cp.GetRateLimit error")
+			AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion")
+		})
+	}
+}
+
+func TestChainGetRateLimit(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{}),
+		NewPrefixConfigProvider("export.", NewInMemoryConfigProvider(map[string]string{"export.limit": "500/m"})),
+	})
+
+	value, err := cp.GetRateLimit("limit")
+	AssertEquals(t, nil, err, "cp.GetRateLimit error")
+	AssertEquals(t, RateLimit{Count: 500, Per: time.Minute}, value, "cp.GetRateLimit value")
+}
+
+func TestQuotedValues(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/quoted.properties")
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"greeting", "hello world"},
+		{"padded", "  padded  "},
+		{"single", "  single # quoted  "},
+		{"comment", "quoted"},
+		{"unquoted", "trimmed value"},
+		{"empty", ""},
+		{"apostrophe", "it's fine"},
+		{"list", `"a,b", c`},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetString(test.key)
+		AssertEquals(t, nil, err, "cp.GetString error")
+		AssertEquals(t, test.expected, value, "cp.GetString value")
+	}
+}
+
+func TestTrimSpace(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/whitespace.properties")
+
+	expected := []string{"postgres.host", "postgres.password", "postgres.port", "postgres.user"}
+	AssertEquals(t, expected, cp.Keys(), "cp.Keys")
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"postgres.host", "localhost"},
+		{"postgres.port", "5432"},
+		{"postgres.user", "solvent"},
+		{"postgres.password", "  secret  "},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetString(test.key)
+		AssertEquals(t, nil, err, "cp.GetString error")
+		AssertEquals(t, test.expected, value, "cp.GetString value")
+	}
+}
+
+func TestWithoutTrimSpace(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/whitespace.properties", WithTrimSpace(false))
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"postgres.host", " localhost"},
+		{"postgres.port", "\t5432  "},
+		{"postgres.user", "  solvent "},
+		{"postgres.password", "  secret  "},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetString(test.key)
+		AssertEquals(t, nil, err, "cp.GetString error")
+		AssertEquals(t, test.expected, value, "cp.GetString value")
+	}
+}
+
+func TestGetPort(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetPort("port")
+	AssertEquals(t, nil, err, "cp.GetPort error")
+	AssertEquals(t, 8080, value, "cp.GetPort value")
+
+	_, err = cp.GetPort("port.typo")
+	AssertEquals(t, NewTypeConversionError("port.typo", "808o", "int"), err, "cp.GetPort error")
+
+	_, err = cp.GetPort("port.high")
+	expectedErr := newTypeConversionErrorWithCause("port.high", "65536", "port", errors.New("port has to be in the range 1-65535"))
+	AssertEquals(t, expectedErr, err, "cp.GetPort error")
+
+	_, err = cp.GetPort("port.zero")
+	expectedErr = newTypeConversionErrorWithCause("port.zero", "0", "port", errors.New("port has to be in the range 1-65535"))
+	AssertEquals(t, expectedErr, err, "cp.GetPort error")
+}
+
+func TestGetPortWithZeroPort(t *testing.T) {
+	cp := NewFileConfigProvider(testFile, WithZeroPort())
+
+	value, err := cp.GetPort("port.zero")
+	AssertEquals(t, nil, err, "cp.GetPort error")
+	AssertEquals(t, 0, value, "cp.GetPort value")
+}
+
+func TestBlankLines(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/editor.properties")
+
+	AssertEquals(t, []string{"postgres.host", "postgres.port"}, cp.Keys(), "cp.Keys")
+
+	value, err := cp.GetString("postgres.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "localhost", value, "cp.GetString value")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	err := os.WriteFile(path, []byte(content), 0644)
+	AssertEquals(t, nil, err, "os.WriteFile error")
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reload.properties")
+	writeFile(t, path, "port=8080\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	value, err := cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 8080, value, "cp.GetInt value")
+
+	writeFile(t, path, "port=9090\nhost=localhost\n")
+	AssertEquals(t, nil, cp.Reload(), "cp.Reload error")
+
+	value, err = cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 9090, value, "cp.GetInt value")
+	AssertEquals(t, true, cp.Has("host"), "cp.Has host")
+
+	writeFile(t, path, "invalid\n")
+	AssertEquals(t, NewParsingErrorAt(1, "invalid"), cp.Reload(), "cp.Reload error")
+
+	value, err = cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 9090, value, "cp.GetInt value")
+}
+
+func TestReloadAndSetInvalidateCachedValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reload.properties")
+	writeFile(t, path, "feature.x=true\nratio=0.5\npattern=^a+$\nschedule=0 3 * * *\n")
+	cp := newFileConfigProvider(path, false, nil)
+	now := time.Date(2024, 3, 13, 10, 0, 0, 0, time.UTC)
+
+	assertValues := func(flag bool, ratio float64, input string, next time.Time) {
+		t.Helper()
+		for i := 0; i < 2; i++ {
+			flagValue, err := cp.GetBool("feature.x")
+			AssertEquals(t, nil, err, "cp.GetBool error")
+			AssertEquals(t, flag, flagValue, "cp.GetBool value")
+
+			ratioValue, err := cp.GetFloat("ratio")
+			AssertEquals(t, nil, err, "cp.GetFloat error")
+			AssertEquals(t, ratio, ratioValue, "cp.GetFloat value")
+
+			pattern, err := cp.GetRegexp("pattern")
+			AssertEquals(t, nil, err, "cp.GetRegexp error")
+			AssertEquals(t, true, pattern.MatchString(input), "pattern.MatchString")
+
+			schedule, err := cp.GetCron("schedule")
+			AssertEquals(t, nil, err, "cp.GetCron error")
+			AssertEquals(t, next, schedule.Next(now), "schedule.Next")
+		}
+	}
+
+	assertValues(true, 0.5, "aa", time.Date(2024, 3, 14, 3, 0, 0, 0, time.UTC))
+
+	writeFile(t, path, "feature.x=false\nratio=0.25\npattern=^b+$\nschedule=30 12 * * *\n")
+	AssertEquals(t, nil, cp.Reload(), "cp.Reload error")
+	assertValues(false, 0.25, "bb", time.Date(2024, 3, 13, 12, 30, 0, 0, time.UTC))
+
+	cp.Set("feature.x", "true")
+	cp.Set("ratio", "1")
+	cp.Set("pattern", "^c+$")
+	cp.Set("schedule", "@hourly")
+	assertValues(true, 1, "cc", time.Date(2024, 3, 13, 11, 0, 0, 0, time.UTC))
+}
+
+// BenchmarkGetBool and BenchmarkGetFloat show that parsing cheap values
+// costs about as much as a lookup in the parse cache of typedGetters
+// which is why only expensive values like regexps or cron expressions
+// are cached
+func BenchmarkGetBool(b *testing.B) {
+	cp := NewInMemoryConfigProvider(map[string]string{"feature.x": "true"})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := cp.GetBool("feature.x"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetBoolParseCache(b *testing.B) {
+	cp := NewInMemoryConfigProvider(map[string]string{"feature.x": "true"})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		raw, _ := cp.GetString("feature.x")
+		_, err := cp.cached("bool", "feature.x", raw, func() (interface{}, error) {
+			return strconv.ParseBool(raw)
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetFloat(b *testing.B) {
+	cp := NewInMemoryConfigProvider(map[string]string{"ratio": "0.123456789"})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := cp.GetFloat("ratio"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetFloatParseCache(b *testing.B) {
+	cp := NewInMemoryConfigProvider(map[string]string{"ratio": "0.123456789"})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		raw, _ := cp.GetString("ratio")
+		_, err := cp.cached("float64", "ratio", raw, func() (interface{}, error) {
+			return strconv.ParseFloat(raw, 64)
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestReloadDropsRemovedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reload.properties")
+	writeFile(t, path, "port=8080\nhost=localhost\n")
+	cp := newFileConfigProvider(path, false, nil)
+	AssertEquals(t, true, cp.Has("host"), "cp.Has host")
+
+	writeFile(t, path, "port=8080\n")
+	AssertEquals(t, nil, cp.Reload(), "cp.Reload error")
+
+	_, err := cp.GetString("host")
+	AssertEquals(t, NewKeyNotFoundError("host"), err, "cp.GetString error")
+	AssertEquals(t, []string{"port"}, cp.Keys(), "cp.Keys")
+}
+
+func TestConcurrentReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reload.properties")
+	writeFile(t, path, "port=8080\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := cp.GetInt("port")
+			AssertEquals(t, nil, err, "cp.GetInt error")
+		}()
+		go func() {
+			defer wg.Done()
+			AssertEquals(t, nil, cp.Reload(), "cp.Reload error")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentFirstLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "first-load.properties")
+	writeFile(t, path, "port=8080\npattern=^a+$\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			AssertEquals(t, true, cp.Has("port"), "cp.Has")
+		}()
+		go func() {
+			defer wg.Done()
+			AssertEquals(t, []string{"pattern", "port"}, cp.Keys(), "cp.Keys")
+		}()
+		go func() {
+			defer wg.Done()
+			re, err := cp.GetRegexp("pattern")
+			AssertEquals(t, nil, err, "cp.GetRegexp error")
+			AssertEquals(t, true, re.MatchString("aaa"), "cp.GetRegexp match")
+		}()
+		go func() {
+			defer wg.Done()
+			AssertEquals(t, nil, cp.Reload(), "cp.Reload error")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAbsolutePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "absolute.properties")
+	writeFile(t, path, "host=localhost\n")
+	cp := NewFileConfigProvider(path)
+
+	value, err := cp.GetString("host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "localhost", value, "cp.GetString value")
+}
+
+func TestWorkingDirRelativePath(t *testing.T) {
+	cp := NewFileConfigProviderRelative(testFile)
+
+	value, err := cp.GetString("string")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "value", value, "cp.GetString value")
+
+	workingDir, _ := os.Getwd()
+	AssertEquals(t, filepath.Join(workingDir, testFile), cp.path, "cp.path")
+}
+
+func TestGetLogLevel(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		key      string
+		expected slog.Level
+	}{
+		{"log.level", slog.LevelDebug},
+		{"log.level.offset", slog.LevelInfo + 2},
+		{"log.level.numeric", slog.Level(12)},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetLogLevel(test.key)
+		AssertEquals(t, nil, err, "cp.GetLogLevel error")
+		AssertEquals(t, test.expected, value, "cp.GetLogLevel value")
+	}
+
+	_, err := cp.GetLogLevel("log.level.invalid")
+	AssertEquals(t, NewTypeConversionError("log.level.invalid", "verbose", "slog.Level"), err, "cp.GetLogLevel error")
+}
+
+func TestGetLogLevelOrDefault(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_LOG_LEVEL", "error")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	value, err := cp.GetLogLevelOrDefault("log.level", slog.LevelInfo)
+	AssertEquals(t, nil, err, "cp.GetLogLevelOrDefault error")
+	AssertEquals(t, slog.LevelError, value, "cp.GetLogLevelOrDefault value")
+
+	value, err = cp.GetLogLevelOrDefault("missing", slog.LevelInfo)
+	AssertEquals(t, nil, err, "cp.GetLogLevelOrDefault error")
+	AssertEquals(t, slog.LevelInfo, value, "cp.GetLogLevelOrDefault value")
+
+	fileCp := NewFileConfigProvider(testFile)
+	_, err = fileCp.GetLogLevelOrDefault("log.level.invalid", slog.LevelInfo)
+	AssertEquals(t, NewTypeConversionError("log.level.invalid", "verbose", "slog.Level"), err, "cp.GetLogLevelOrDefault error")
+}
+
+func TestChainStopsOnConversionError(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_STORAGE", "postgress")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewJSONConfigProviderFromReader(strings.NewReader(`{}`)),
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewJSONConfigProviderFromReader(strings.NewReader(`{"storage": "memory"}`)),
+	})
+
+	_, err := cp.GetEnum("storage", "postgres", "sqlite", "memory")
+	expectedErr := NewChainError("storage", []error{
+		NewInvalidEnumError("storage", "postgress", []string{"postgres", "sqlite", "memory"}),
+	})
+	AssertEquals(t, expectedErr, err, "cp.GetEnum error")
+
+	var enumErr *InvalidEnumError
+	AssertEquals(t, true, errors.As(err, &enumErr), "errors.As InvalidEnumError")
+}
+
+func TestChainMissingThenInvalid(t *testing.T) {
+	newChain := func() *ChainConfigProvider {
+		return NewChainConfigProvider([]ConfigProvider{
+			NewInMemoryConfigProvider(map[string]string{}),
+			NewInMemoryConfigProvider(map[string]string{"port": "notanumber"}),
+		})
+	}
+	expectedErr := NewChainError("port", []error{NewTypeConversionError("port", "notanumber", "int")})
+
+	_, err := newChain().GetInt("port")
+	AssertEquals(t, expectedErr, err, "cp.GetInt error")
+	AssertEquals(t, false, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound")
+	AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion")
+
+	nested := NewChainConfigProvider([]ConfigProvider{
+		newChain(),
+		NewInMemoryConfigProvider(map[string]string{"port": "8080"}),
+	})
+	_, err = nested.GetInt("port")
+	AssertEquals(t, NewChainError("port", []error{expectedErr}), err, "nested.GetInt error")
+
+	prefixed := NewPrefixConfigProvider("app.", NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{}),
+		NewInMemoryConfigProvider(map[string]string{"app.port": "notanumber"}),
+	}))
+	_, err = prefixed.GetInt("port")
+	AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "prefixed errors.Is ErrTypeConversion")
+	AssertEquals(t, false, errors.Is(err, ErrKeyNotFound), "prefixed errors.Is ErrKeyNotFound")
+
+	err = newChain().ValidateKinds(map[string]Kind{"port": KindInt})
+	AssertEquals(t, NewValidationError([]string{}, []error{expectedErr}), err, "cp.ValidateKinds error")
+}
+
+func TestGetEnum(t *testing.T) {
+	cp := NewJSONConfigProviderFromReader(strings.NewReader(`{"storage": "SQLite"}`))
+
+	_, err := cp.GetEnum("storage", "postgres", "sqlite", "memory")
+	AssertEquals(t, NewInvalidEnumError("storage", "SQLite", []string{"postgres", "sqlite", "memory"}), err, "cp.GetEnum error")
+	AssertEquals(t, `value 'SQLite' of key 'storage' is not one of ["postgres" "sqlite" "memory"]`, err.Error(), "cp.GetEnum error message")
+
+	value, err := cp.GetEnum("storage", "postgres", "SQLite")
+	AssertEquals(t, nil, err, "cp.GetEnum error")
+	AssertEquals(t, "SQLite", value, "cp.GetEnum value")
+
+	cp = NewJSONConfigProviderFromReader(strings.NewReader(`{"storage": "SQLite"}`), WithCaseInsensitiveEnums())
+
+	value, err = cp.GetEnum("storage", "postgres", "sqlite", "memory")
+	AssertEquals(t, nil, err, "cp.GetEnum error")
+	AssertEquals(t, "sqlite", value, "cp.GetEnum value")
+}
+
+type testRollout struct {
+	Percent int      `json:"percent"`
+	Tenants []string `json:"tenants"`
+}
+
+type testFlag struct {
+	Name    string          `json:"name"`
+	Rollout testRollout     `json:"rollout"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func TestGetStruct(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	var flag testFlag
+	err := cp.GetStruct("struct.flag", &flag)
+	AssertEquals(t, nil, err, "cp.GetStruct error")
+	AssertEquals(t, testFlag{
+		Name:    "beta",
+		Rollout: testRollout{Percent: 10, Tenants: []string{"a", "b"}},
+		Payload: json.RawMessage(`{"raw": true}`),
+	}, flag, "cp.GetStruct value")
+
+	var raw json.RawMessage
+	err = cp.GetStruct("struct.flag", &raw)
+	AssertEquals(t, nil, err, "cp.GetStruct error")
+	AssertEquals(t, `{"name": "beta", "rollout": {"percent": 10, "tenants": ["a", "b"]}, "payload": {"raw": true}}`, string(raw), "cp.GetStruct value")
+
+	err = cp.GetStruct("struct.missing", &flag)
+	AssertEquals(t, NewKeyNotFoundError("struct.missing"), err, "cp.GetStruct error")
+
+	fileCp := NewFileConfigProvider(testFile)
+
+	err = fileCp.GetStruct("struct.empty", &flag)
+	AssertEquals(t, "value '' of key 'struct.empty' cannot be converted to expected type 'conf.testFlag': value is empty", err.Error(), "cp.GetStruct error")
+
+	err = fileCp.GetStruct("struct.invalid", &flag)
+	var conversionErr *TypeConversionError
+	AssertEquals(t, true, errors.As(err, &conversionErr), "errors.As TypeConversionError")
+	AssertEquals(t, "conf.testFlag", conversionErr.Type, "conversionErr.Type")
+
+	var syntaxErr *json.SyntaxError
+	AssertEquals(t, true, errors.As(err, &syntaxErr), "errors.As json.SyntaxError")
+}
+
+func TestGetLocation(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"scheduler.timezone", "Europe/Vienna"},
+		{"scheduler.timezone.utc", "UTC"},
+		{"scheduler.timezone.local", "Local"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := cp.GetLocation(test.key)
+			AssertEquals(t, nil, err, "cp.GetLocation error")
+			AssertEquals(t, test.expected, value.String(), "cp.GetLocation value")
+		})
+	}
+
+	value, err := cp.GetLocation("scheduler.timezone")
+	AssertEquals(t, nil, err, "cp.GetLocation error")
+	cached, err := cp.GetLocation("scheduler.timezone")
+	AssertEquals(t, nil, err, "cp.GetLocation error")
+	AssertEquals(t, true, value == cached, "cp.GetLocation cached")
+
+	_, err = cp.GetLocation("scheduler.timezone.invalid")
+	AssertEquals(t, "timezone", err.(*TypeConversionError).Type, "cp.GetLocation error type")
+
+	_, err = cp.GetLocation("struct.empty")
+	AssertEquals(t, "timezone", err.(*TypeConversionError).Type, "cp.GetLocation error type")
+
+	chain := NewChainConfigProvider([]ConfigProvider{NewEnvConfigProvider("SOLVENT_TEST_"), cp})
+	value, err = chain.GetLocation("scheduler.timezone.utc")
+	AssertEquals(t, nil, err, "chain.GetLocation error")
+	AssertEquals(t, time.UTC, value, "chain.GetLocation value")
+}
+
+func TestGetUUID(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+	expected := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	for _, key := range []string{"uuid", "uuid.braced"} {
+		t.Run(key, func(t *testing.T) {
+			value, err := cp.GetUUID(key)
+			AssertEquals(t, nil, err, "cp.GetUUID error")
+			AssertEquals(t, expected, value, "cp.GetUUID value")
+			AssertEquals(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", value.String(), "cp.GetUUID canonical form")
+		})
+	}
+
+	_, err := cp.GetUUID("uuid.invalid")
+	AssertEquals(t, "uuid.UUID", err.(*TypeConversionError).Type, "cp.GetUUID error type")
+
+	rejected := NewInMemoryConfigProvider(map[string]string{
+		"urn":       "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"unhyphen":  "6ba7b8109dad11d180b400c04fd430c8",
+		"microsoft": "6ba7b810-9dad-11d1-c0b4-00c04fd430c8",
+		"ncs":       "6ba7b810-9dad-11d1-00b4-00c04fd430c8",
+	})
+	for _, key := range rejected.Keys() {
+		_, err := rejected.GetUUID(key)
+		AssertEquals(t, true, errors.Is(err, ErrTypeConversion), key+" errors.Is ErrTypeConversion")
+	}
+
+	_, err = cp.GetUUID("missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "cp.GetUUID error")
+}
+
+// The providers of the package implement the getters that are not part
+// of ConfigProvider
+var (
+	_ extendedGetters = (*ChainConfigProvider)(nil)
+	_ extendedGetters = (*DefaultingConfigProvider)(nil)
+	_ extendedGetters = (*DotenvConfigProvider)(nil)
+	_ extendedGetters = (*EnvConfigProvider)(nil)
+	_ extendedGetters = (*FileConfigProvider)(nil)
+	_ extendedGetters = (*InMemoryConfigProvider)(nil)
+	_ extendedGetters = (*JSONFileConfigProvider)(nil)
+	_ extendedGetters = (*PrefixConfigProvider)(nil)
+	_ extendedGetters = (*ReaderConfigProvider)(nil)
+	_ extendedGetters = (*TOMLFileConfigProvider)(nil)
+	_ extendedGetters = (*YAMLFileConfigProvider)(nil)
+)
+
+// basicProvider only has the methods of ConfigProvider like providers
+// implemented outside of the package
+type basicProvider struct {
+	ConfigProvider
+}
+
+func TestExtendedGettersOfBasicProvider(t *testing.T) {
+	inner := basicProvider{NewInMemoryConfigProvider(map[string]string{
+		"app.id":      "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"app.version": "1.4.0",
+		"app.invalid": "garbage",
+	})}
+	chain := NewChainConfigProvider([]ConfigProvider{inner})
+	prefix := NewPrefixConfigProvider("app.", inner)
+
+	id, err := chain.GetUUID("app.id")
+	AssertEquals(t, nil, err, "chain.GetUUID error")
+	AssertEquals(t, uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), id, "chain.GetUUID value")
+
+	version, err := prefix.GetSemver("version")
+	AssertEquals(t, nil, err, "prefix.GetSemver error")
+	AssertEquals(t, Semver{Major: 1, Minor: 4}, version, "prefix.GetSemver value")
+
+	_, err = prefix.GetSemver("invalid")
+	AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion")
+
+	_, err = chain.GetCron("missing")
+	AssertEquals(t, true, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound")
+}
+
+func TestGetHostPort(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		key  string
+		host string
+		port int
+	}{
+		{"redis.addr", "cache.internal", 6379},
+		{"redis.addr.ipv6", "::1", 6379},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			host, port, err := cp.GetHostPort(test.key)
+			AssertEquals(t, nil, err, "cp.GetHostPort error")
+			AssertEquals(t, test.host, host, "cp.GetHostPort host")
+			AssertEquals(t, test.port, port, "cp.GetHostPort port")
+		})
+	}
+
+	for _, key := range []string{"redis.addr.noport", "redis.addr.range", "redis.addr.invalid"} {
+		t.Run(key, func(t *testing.T) {
+			_, _, err := cp.GetHostPort(key)
+			AssertEquals(t, "host:port", err.(*TypeConversionError).Type, "cp.GetHostPort error type")
+		})
+	}
+}
+
+func TestGetHostPortDefault(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	tests := []struct {
+		key  string
+		host string
+		port int
+	}{
+		{"redis.addr", "cache.internal", 6379},
+		{"redis.addr.noport", "cache.internal", 6380},
+		{"redis.addr.ipv6.noport", "fe80::1", 6380},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			host, port, err := cp.GetHostPortDefault(test.key, 6380)
+			AssertEquals(t, nil, err, "cp.GetHostPortDefault error")
+			AssertEquals(t, test.host, host, "cp.GetHostPortDefault host")
+			AssertEquals(t, test.port, port, "cp.GetHostPortDefault port")
+		})
+	}
+
+	_, _, err := cp.GetHostPortDefault("redis.addr.range", 6380)
+	AssertEquals(t, NewChainError("redis.addr.range", []error{
+		newTypeConversionErrorWithCause("redis.addr.range", "cache.internal:70000", "host:port", errors.New("port has to be in the range 1-65535")),
+	}), err, "cp.GetHostPortDefault error")
+}
+
+func TestCaseInsensitiveKeys(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/case.properties", WithCaseInsensitiveKeys())
+
+	for _, key := range []string{"server.port", "Server.Port", "SERVER.PORT", "sErVeR.pOrT"} {
+		t.Run(key, func(t *testing.T) {
+			value, err := cp.GetInt(key)
+			AssertEquals(t, nil, err, "cp.GetInt error")
+			AssertEquals(t, 8080, value, "cp.GetInt value")
+		})
+	}
+
+	_, err := cp.GetString("Server.Host")
+	AssertEquals(t, NewKeyNotFoundError("Server.Host"), err, "cp.GetString error")
+	AssertEquals(t, []string{"log.level", "server.port"}, cp.Keys(), "cp.Keys")
+
+	caseSensitive := NewFileConfigProvider("testdata/case.properties")
+	_, err = caseSensitive.GetString("server.port")
+	AssertEquals(t, NewKeyNotFoundError("server.port"), err, "caseSensitive.GetString error")
+}
+
+func TestCaseInsensitiveKeyCollision(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/case-collision.properties", WithCaseInsensitiveKeys())
+
+	_, err := cp.GetString("host")
+	AssertEquals(t, newKeyCollisionError(3, "PORT=9090", "PORT", "Port"), err, "cp.GetString error")
+	AssertEquals(t, "could not parse line 3: 'PORT=9090': key 'PORT' collides with 'Port' when ignoring case", err.Error(), "cp.GetString error message")
+
+	jsonCp := NewJSONConfigProviderFromReader(strings.NewReader(`{"Port": 1, "PORT": 2}`), WithCaseInsensitiveKeys())
+	_, err = jsonCp.GetString("port")
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+}
+
+func TestCaseInsensitiveDocumentKeys(t *testing.T) {
+	cp := NewYAMLConfigProviderFromReader(strings.NewReader("Database:\n  Host: localhost\n"), WithCaseInsensitiveKeys())
+
+	value, err := cp.GetString("DATABASE.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "localhost", value, "cp.GetString value")
+	AssertEquals(t, []string{"database.host"}, cp.Keys(), "cp.Keys")
+}
+
+func TestGetTemplate(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"alert.message": "{{.Service}} is {{.Status}}",
+		"alert.upper":   "{{upper .Service}} is down",
+		"alert.invalid": "{{.Service",
+	})
+	funcs := template.FuncMap{"upper": strings.ToUpper}
+
+	tmpl, err := cp.GetTemplate("alert.message")
+	AssertEquals(t, nil, err, "cp.GetTemplate error")
+	var b strings.Builder
+	AssertEquals(t, nil, tmpl.Execute(&b, map[string]string{"Service": "db", "Status": "down"}), "tmpl.Execute error")
+	AssertEquals(t, "db is down", b.String(), "tmpl.Execute output")
+
+	cached, err := cp.GetTemplate("alert.message")
+	AssertEquals(t, nil, err, "cp.GetTemplate error")
+	AssertEquals(t, true, tmpl == cached, "cp.GetTemplate cached")
+
+	cp.Set("alert.message", "{{.Service}} changed")
+	changed, err := cp.GetTemplate("alert.message")
+	AssertEquals(t, nil, err, "cp.GetTemplate error")
+	AssertEquals(t, false, tmpl == changed, "cp.GetTemplate reparsed")
+
+	_, err = cp.GetTemplate("alert.upper")
+	AssertEquals(t, "*template.Template", err.(*TypeConversionError).Type, "cp.GetTemplate error type")
+
+	tmpl, err = cp.GetTemplate("alert.upper", funcs)
+	AssertEquals(t, nil, err, "cp.GetTemplate error")
+	b.Reset()
+	AssertEquals(t, nil, tmpl.Execute(&b, map[string]string{"Service": "db"}), "tmpl.Execute error")
+	AssertEquals(t, "DB is down", b.String(), "tmpl.Execute output")
+
+	// Templates with function maps are parsed per call and never cached
+	// so fresh maps neither grow the cache nor return stale functions
+	lower := template.FuncMap{"upper": strings.ToLower}
+	tmpl, err = cp.GetTemplate("alert.upper", lower)
+	AssertEquals(t, nil, err, "cp.GetTemplate error")
+	b.Reset()
+	AssertEquals(t, nil, tmpl.Execute(&b, map[string]string{"Service": "DB"}), "tmpl.Execute error")
+	AssertEquals(t, "db is down", b.String(), "tmpl.Execute output")
+
+	for i := 0; i < 10; i++ {
+		_, err = cp.GetTemplate("alert.upper", template.FuncMap{"upper": strings.ToUpper})
+		AssertEquals(t, nil, err, "cp.GetTemplate error")
+	}
+	entries := 0
+	cp.cache.Range(func(k, _ interface{}) bool {
+		if k.(cacheKey).key == "alert.upper" {
+			entries++
+		}
+		return true
+	})
+	AssertEquals(t, 0, entries, "cached alert.upper templates")
+
+	_, err = cp.GetTemplate("alert.invalid")
+	AssertEquals(t, "*template.Template", err.(*TypeConversionError).Type, "cp.GetTemplate error type")
+}
+
+func TestSections(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/sections.properties")
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"name", "solvent"},
+		{"database.host", "localhost"},
+		{"database.port", "5432"},
+		{"server.tls.cert_file", "/etc/cert.pem"},
+		{"root", "again"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := cp.GetString(test.key)
+			AssertEquals(t, nil, err, "cp.GetString error")
+			AssertEquals(t, test.expected, value, "cp.GetString value")
+		})
+	}
+
+	_, err := cp.GetString("host")
+	AssertEquals(t, NewKeyNotFoundError("host"), err, "cp.GetString error")
+
+	expected := []string{"database.host", "database.port", "name", "root", "server.tls.cert_file"}
+	AssertEquals(t, expected, cp.Keys(), "cp.Keys")
+}
+
+func TestAllParsingErrors(t *testing.T) {
+	cp := NewFileConfigProvider("testdata/many-errors.properties", WithAllParsingErrors())
+
+	_, err := cp.GetString("valid")
+	expected := NewParsingErrors([]error{
+		NewParsingErrorAt(2, "first invalid"),
+		NewParsingErrorAt(4, "second invalid"),
+		NewParsingErrorAt(6, "third invalid"),
+	})
+	AssertEquals(t, expected, err, "cp.GetString error")
+	AssertEquals(t, "3 lines could not be parsed: could not parse line 2: 'first invalid'; could not parse line 4: 'second invalid'; could not parse line 6: 'third invalid'", err.Error(), "cp.GetString error message")
+
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+	AssertEquals(t, 2, parsingErr.LineNumber, "parsingErr.LineNumber")
+
+	cp = NewFileConfigProvider("testdata/many-errors.properties")
+	_, err = cp.GetString("valid")
+	AssertEquals(t, NewParsingErrorAt(2, "first invalid"), err, "cp.GetString error")
+}
+
+func TestGetMailAddress(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"alerts.from":    "SRE <sre@example.com>",
+		"alerts.invalid": "sre@",
+		"alerts.to":      `ops@example.com, "Doe, Jane" <jane@example.com>,SRE <sre@example.com>`,
+		"alerts.broken":  "ops@example.com, not an address",
+	})
+
+	value, err := cp.GetMailAddress("alerts.from")
+	AssertEquals(t, nil, err, "cp.GetMailAddress error")
+	AssertEquals(t, &mail.Address{Name: "SRE", Address: "sre@example.com"}, value, "cp.GetMailAddress value")
+
+	_, err = cp.GetMailAddress("alerts.invalid")
+	AssertEquals(t, "*mail.Address", err.(*TypeConversionError).Type, "cp.GetMailAddress error type")
+
+	list, err := cp.GetMailAddressList("alerts.to")
+	AssertEquals(t, nil, err, "cp.GetMailAddressList error")
+	AssertEquals(t, []*mail.Address{
+		{Address: "ops@example.com"},
+		{Name: "Doe, Jane", Address: "jane@example.com"},
+		{Name: "SRE", Address: "sre@example.com"},
+	}, list, "cp.GetMailAddressList value")
+
+	_, err = cp.GetMailAddressList("alerts.broken")
+	expectedErr := newSliceElementError("alerts.broken", "ops@example.com, not an address", "[]*mail.Address", 1, "not an address")
+	AssertEquals(t, expectedErr, err, "cp.GetMailAddressList error")
+}
+
+func TestGetPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "paths.properties")
+	writeFile(t, path, "tls.cert_file=certs/../certs/server.pem\ntls.ca_file=/etc/ssl/ca.pem\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	value, err := cp.GetPath("tls.cert_file")
+	AssertEquals(t, nil, err, "cp.GetPath relative error")
+	AssertEquals(t, filepath.Join(dir, "certs", "server.pem"), value, "cp.GetPath relative value")
+
+	value, err = cp.GetPath("tls.ca_file")
+	AssertEquals(t, nil, err, "cp.GetPath absolute error")
+	AssertEquals(t, "/etc/ssl/ca.pem", value, "cp.GetPath absolute value")
+
+	_, err = cp.GetPath("tls.key_file")
+	AssertEquals(t, NewKeyNotFoundError("tls.key_file"), err, "cp.GetPath missing key")
+}
+
+func TestGetPathExisting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "paths.properties")
+	writeFile(t, path, "tls.cert_file=server.pem\ntls.key_file=server.key\n")
+	writeFile(t, filepath.Join(dir, "server.pem"), "cert")
+	cp := newFileConfigProvider(path, false, []Option{WithExistingPaths()})
+
+	value, err := cp.GetPath("tls.cert_file")
+	AssertEquals(t, nil, err, "cp.GetPath error")
+	AssertEquals(t, filepath.Join(dir, "server.pem"), value, "cp.GetPath value")
+
+	_, err = cp.GetPath("tls.key_file")
+	var pathErr *PathNotFoundError
+	AssertEquals(t, true, errors.As(err, &pathErr), "errors.As PathNotFoundError")
+	AssertEquals(t, filepath.Join(dir, "server.key"), pathErr.Path, "PathNotFoundError.Path")
+	AssertEquals(t, true, errors.Is(err, os.ErrNotExist), "errors.Is os.ErrNotExist")
+}
+
+func TestChainGetPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "paths.properties")
+	writeFile(t, path, "tls.cert_file=server.pem\n")
+	t.Setenv("SOLVENT_TEST_TLS_KEY_FILE", "server.key")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		newFileConfigProvider(path, false, nil),
+	})
+
+	value, err := cp.GetPath("tls.cert_file")
+	AssertEquals(t, nil, err, "cp.GetPath file error")
+	AssertEquals(t, filepath.Join(dir, "server.pem"), value, "cp.GetPath file value")
+
+	workingDir, err := os.Getwd()
+	AssertEquals(t, nil, err, "os.Getwd error")
+	value, err = cp.GetPath("tls.key_file")
+	AssertEquals(t, nil, err, "cp.GetPath env error")
+	AssertEquals(t, filepath.Join(workingDir, "server.key"), value, "cp.GetPath env value")
+}
+
+func TestGetBoolLenient(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"yes": "Yes", "n": "n", "on": "ON", "off": "off", "enabled": "enabled",
+		"disabled": "Disabled", "strict": "TRUE", "ambiguous": "maybe",
+	}, WithLenientBool())
+
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{"yes", true},
+		{"n", false},
+		{"on", true},
+		{"off", false},
+		{"enabled", true},
+		{"disabled", false},
+		{"strict", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := cp.GetBool(test.key)
+			AssertEquals(t, nil, err, "cp.GetBool error")
+			AssertEquals(t, test.expected, value, "cp.GetBool value")
+		})
+	}
+
+	_, err := cp.GetBool("ambiguous")
+	var conversionErr *TypeConversionError
+	AssertEquals(t, true, errors.As(err, &conversionErr), "errors.As TypeConversionError")
+	AssertEquals(t, true, strings.Contains(err.Error(), "yes, no, y, n, on, off, enabled, disabled"), "error lists accepted tokens")
+
+	strict := NewInMemoryConfigProvider(map[string]string{"yes": "yes"})
+	_, err = strict.GetBool("yes")
+	AssertEquals(t, NewTypeConversionError("yes", "yes", "bool"), err, "strict.GetBool error")
+}
+
+func TestGetURLWithPort(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{"endpoint": "https://example.com:8443"})
+
+	value, err := cp.GetURL("endpoint", "https")
+	AssertEquals(t, nil, err, "cp.GetURL error")
+	AssertEquals(t, "example.com:8443", value.Host, "cp.GetURL host")
+}
+
+func TestAll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "all.properties")
+	writeFile(t, path, "host=localhost\nport=8080\nurl=http://${host}:${port}\n")
+	file := newFileConfigProvider(path, false, []Option{WithInterpolation()})
+
+	values, err := file.All()
+	AssertEquals(t, nil, err, "file.All error")
+	AssertEquals(t, map[string]string{"host": "localhost", "port": "8080", "url": "http://localhost:8080"}, values, "file.All values")
+
+	values["host"] = "changed"
+	host, err := file.GetString("host")
+	AssertEquals(t, nil, err, "file.GetString error")
+	AssertEquals(t, "localhost", host, "file.GetString after modifying All")
+
+	t.Setenv("SOLVENT_ALL_PORT", "9090")
+	chain := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_ALL_"),
+		NewInMemoryConfigProvider(map[string]string{"port": "7070", "debug": "true"}),
+		file,
+	})
+
+	values, err = chain.All()
+	AssertEquals(t, nil, err, "chain.All error")
+	AssertEquals(t, map[string]string{
+		"host":  "localhost",
+		"port":  "9090",
+		"url":   "http://localhost:8080",
+		"debug": "true",
+	}, values, "chain.All values")
+
+	_, err = NewChainConfigProvider([]ConfigProvider{
+		NewFileConfigProvider("testdata/missing.properties"),
+	}).All()
+	AssertEquals(t, true, err != nil, "chain.All error for missing file")
+}
+
+func TestHasErr(t *testing.T) {
+	file := NewFileConfigProvider(testFile)
+
+	has, err := file.HasErr("string")
+	AssertEquals(t, nil, err, "file.HasErr error")
+	AssertEquals(t, true, has, "file.HasErr present")
+
+	has, err = file.HasErr("tracing.endpoint")
+	AssertEquals(t, nil, err, "file.HasErr error")
+	AssertEquals(t, false, has, "file.HasErr absent")
+
+	unreadable := NewFileConfigProvider("testdata/missing.properties")
+	has, err = unreadable.HasErr("string")
+	AssertEquals(t, true, err != nil, "unreadable.HasErr error")
+	AssertEquals(t, false, has, "unreadable.HasErr value")
+	AssertEquals(t, false, unreadable.Has("string"), "unreadable.Has")
+
+	chain := NewChainConfigProvider([]ConfigProvider{NewInMemoryConfigProvider(nil), file})
+	has, err = chain.HasErr("string")
+	AssertEquals(t, nil, err, "chain.HasErr error")
+	AssertEquals(t, true, has, "chain.HasErr present")
+
+	_, err = NewChainConfigProvider([]ConfigProvider{unreadable, file}).HasErr("string")
+	var chainErr *ChainError
+	AssertEquals(t, true, errors.As(err, &chainErr), "errors.As ChainError")
+}
+
+func TestNamedChainErrors(t *testing.T) {
+	t.Setenv("SOLVENT_NAMED_PORT", "http")
+	cp := NewNamedChainConfigProvider([]NamedConfigProvider{
+		{"env", NewEnvConfigProvider("SOLVENT_NAMED_")},
+		{"file:" + testFile, NewFileConfigProvider(testFile)},
+		{"defaults", NewInMemoryConfigProvider(map[string]string{"timeout": "5s"})},
+	})
+
+	timeout, err := cp.GetDuration("timeout")
+	AssertEquals(t, nil, err, "cp.GetDuration error")
+	AssertEquals(t, 5*time.Second, timeout, "cp.GetDuration value")
+
+	_, err = cp.GetString("db.host")
+	AssertEquals(t, true, isKeyNotFound(err), "isKeyNotFound")
+	AssertEquals(t, "config value with key 'db.host' could not be found in [env, file:"+testFile+", defaults]",
+		err.Error(), "cp.GetString error")
+
+	_, err = cp.GetInt("port")
+	AssertEquals(t, "config value with key 'port' could not be resolved: env: "+
+		NewTypeConversionError("port", "http", "int").Error(), err.Error(), "cp.GetInt error")
+
+	_, err = cp.GetBool("int")
+	AssertEquals(t, "config value with key 'int' could not be resolved: env: "+
+		NewKeyNotFoundError("int").Error()+"; file:"+testFile+": "+
+		NewTypeConversionError("int", "42", "bool").Error(), err.Error(), "cp.GetBool error")
+}
+
+func TestGetScoped(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, err := cp.GetScoped("scheduler", "timezone", "utc")
+	AssertEquals(t, nil, err, "cp.GetScoped error")
+	AssertEquals(t, "UTC", value, "cp.GetScoped value")
+
+	value, err = cp.GetScoped("string")
+	AssertEquals(t, nil, err, "cp.GetScoped error")
+	AssertEquals(t, "value", value, "cp.GetScoped single segment")
+
+	_, err = cp.GetScoped("scheduler", "timezone", "missing")
+	AssertEquals(t, NewKeyNotFoundError("scheduler.timezone.missing"), err, "cp.GetScoped error")
+}
+
+func TestSentinelErrors(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{NewFileConfigProvider(testFile)})
+
+	_, err := cp.GetString("missing")
+	AssertEquals(t, true, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound")
+	AssertEquals(t, false, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion")
+	var notFoundErr *KeyNotFoundError
+	AssertEquals(t, true, errors.As(err, &notFoundErr), "errors.As KeyNotFoundError")
+
+	_, err = cp.GetInt("string")
+	AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion through ChainError")
+	AssertEquals(t, false, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound")
+	var conversionErr *TypeConversionError
+	AssertEquals(t, true, errors.As(err, &conversionErr), "errors.As TypeConversionError")
+
+	_, err = NewReaderConfigProvider(strings.NewReader("invalid\n")).GetString("key")
+	AssertEquals(t, true, errors.Is(err, ErrParsing), "errors.Is ErrParsing")
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+
+	_, err = NewReaderConfigProvider(strings.NewReader("a\nb\n"), WithAllParsingErrors()).GetString("key")
+	AssertEquals(t, true, errors.Is(err, ErrParsing), "errors.Is ErrParsing through ParsingErrors")
+}
+
+func TestCustomDelimiterAndCommentPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		opts     []Option
+		key      string
+		expected string
+	}{
+		{"colon", "testdata/colon.conf", []Option{WithDelimiter(':'), WithCommentPrefix("//")}, "postgres.host", "localhost"},
+		{"colon without space", "testdata/colon.conf", []Option{WithDelimiter(':'), WithCommentPrefix("//")}, "postgres.port", "5432"},
+		{"colon in value", "testdata/colon.conf", []Option{WithDelimiter(':'), WithCommentPrefix("//")}, "postgres.url", "postgres://localhost:5432/solvent"},
+		{"replaced comment prefix", "testdata/colon.conf", []Option{WithDelimiter(':'), WithCommentPrefix("//")}, "# not a comment", "kept as key"},
+		{"tab", "testdata/tab.conf", []Option{WithDelimiter('\t')}, "postgres.host", "localhost"},
+		{"multiple tabs", "testdata/tab.conf", []Option{WithDelimiter('\t')}, "postgres.port", "5432"},
+		{"tab with spaces", "testdata/tab.conf", []Option{WithDelimiter('\t')}, "postgres.user", "solvent user"},
+		{"tab without trim", "testdata/tab.conf", []Option{WithDelimiter('\t'), WithTrimSpace(false)}, "postgres.port", "\t5432"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cp := NewFileConfigProviderWithOptions(test.path, test.opts...)
+			value, err := cp.GetString(test.key)
+			AssertEquals(t, nil, err, "cp.GetString error")
+			AssertEquals(t, test.expected, value, "cp.GetString value")
+		})
+	}
+}
+
+func TestCustomDelimiterMismatch(t *testing.T) {
+	_, err := NewFileConfigProviderWithOptions("testdata/colon.conf").GetString("postgres.host")
+	AssertEquals(t, true, errors.Is(err, ErrParsing), "errors.Is ErrParsing")
+}
+
+func TestSentinelErrorsThroughWrappers(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	var config ServiceConfig
+	err := UnmarshalConfig(NewInMemoryConfigProvider(map[string]string{"debug": "maybe"}), &config)
+	AssertEquals(t, true, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound through UnmarshalError")
+	AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion through UnmarshalError")
+
+	err = cp.Validate([]string{"missing"})
+	AssertEquals(t, true, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound through ValidationError")
+	AssertEquals(t, false, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion through ValidationError")
+
+	err = cp.ValidateKinds(map[string]Kind{"string": KindInt})
+	AssertEquals(t, false, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound without missing keys")
+	AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion through ValidationError")
+
+	err = recoverPanic(func() { cp.MustGetInt("missing") }).(error)
+	AssertEquals(t, true, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound through MustGetError")
+}
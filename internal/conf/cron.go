@@ -0,0 +1,185 @@
+package conf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed 5-field cron expression
+// ('minute hour day-of-month month day-of-week')
+type CronSchedule struct {
+	minute     cronField
+	hour       cronField
+	dayOfMonth cronField
+	month      cronField
+	dayOfWeek  cronField
+
+	// Like in Vixie cron a day matches either field if both day fields
+	// are restricted and both fields otherwise
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// cronField has bit n set if the value n matches
+type cronField uint64
+
+func (f cronField) has(n int) bool {
+	return f&(1<<uint(n)) != 0
+}
+
+var cronShorthands = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonths = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronWeekdays = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// parseCron parses the standard 5-field cron syntax with lists, ranges,
+// steps and month and weekday names as well as the shorthands '@yearly',
+// '@monthly', '@weekly', '@daily' and '@hourly'
+func parseCron(expression string) (*CronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	if shorthand, ok := cronShorthands[strings.ToLower(expression)]; ok {
+		expression = shorthand
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields but got %d", len(fields))
+	}
+
+	schedule := &CronSchedule{
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}
+	specs := []struct {
+		name     string
+		target   *cronField
+		min, max int
+		names    map[string]int
+	}{
+		{"minute", &schedule.minute, 0, 59, nil},
+		{"hour", &schedule.hour, 0, 23, nil},
+		{"day-of-month", &schedule.dayOfMonth, 1, 31, nil},
+		{"month", &schedule.month, 1, 12, cronMonths},
+		{"day-of-week", &schedule.dayOfWeek, 0, 7, cronWeekdays},
+	}
+	for i, spec := range specs {
+		field, err := parseCronField(fields[i], spec.min, spec.max, spec.names)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field '%s': %s", spec.name, fields[i], err.Error())
+		}
+		*spec.target = field
+	}
+
+	// 7 is an alias for Sunday
+	if schedule.dayOfWeek.has(7) {
+		schedule.dayOfWeek |= 1
+	}
+
+	return schedule, nil
+}
+
+func parseCronField(field string, min, max int, names map[string]int) (cronField, error) {
+	var result cronField
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step '%s'", part[i+1:])
+			}
+			rangePart, step = part[:i], n
+		}
+
+		var low, high int
+		switch bounds := strings.SplitN(rangePart, "-", 2); {
+		case rangePart == "*":
+			low, high = min, max
+		case len(bounds) == 2:
+			var err error
+			if low, err = parseCronValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(bounds[1], names); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if low, err = parseCronValue(rangePart, names); err != nil {
+				return 0, err
+			}
+			high = low
+			if step > 1 {
+				// 'n/step' starts at n and runs until the maximum
+				high = max
+			}
+		}
+
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("range %d-%d is not within %d-%d", low, high, min, max)
+		}
+		for n := low; n <= high; n += step {
+			result |= 1 << uint(n)
+		}
+	}
+
+	return result, nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// Next returns the first time after t that matches the schedule in the
+// location of t or the zero time if there is none within five years
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !s.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !s.hour.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !s.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth.has(t.Day())
+	dayOfWeek := s.dayOfWeek.has(int(t.Weekday()))
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
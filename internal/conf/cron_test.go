@@ -0,0 +1,84 @@
+package conf
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestGetCron(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"cleanup.schedule": "0 3 * * *",
+		"poll":             "*/15 9-17 * * mon-fri",
+		"report":           "30 8 1,15 * *",
+		"friday13":         "0 0 13 * FRI",
+		"sunday":           "0 12 * * 7",
+		"hourly":           "@hourly",
+		"yearly":           "@yearly",
+	})
+	// Wednesday
+	now := time.Date(2024, 3, 13, 10, 7, 42, 0, time.UTC)
+
+	tests := []struct {
+		key      string
+		expected time.Time
+	}{
+		{"cleanup.schedule", time.Date(2024, 3, 14, 3, 0, 0, 0, time.UTC)},
+		{"poll", time.Date(2024, 3, 13, 10, 15, 0, 0, time.UTC)},
+		{"report", time.Date(2024, 3, 15, 8, 30, 0, 0, time.UTC)},
+		{"friday13", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"sunday", time.Date(2024, 3, 17, 12, 0, 0, 0, time.UTC)},
+		{"hourly", time.Date(2024, 3, 13, 11, 0, 0, 0, time.UTC)},
+		{"yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			schedule, err := cp.GetCron(test.key)
+			AssertEquals(t, nil, err, "cp.GetCron error")
+			AssertEquals(t, test.expected, schedule.Next(now), "schedule.Next")
+		})
+	}
+}
+
+func TestGetCronNextAfterMatch(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{"schedule": "0 3 * * *"})
+
+	schedule, err := cp.GetCron("schedule")
+	AssertEquals(t, nil, err, "cp.GetCron error")
+	match := time.Date(2024, 2, 29, 3, 0, 0, 0, time.UTC)
+	AssertEquals(t, time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC), schedule.Next(match), "schedule.Next")
+}
+
+func TestGetCronInvalid(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"hour":   "0 25 * * *",
+		"fields": "0 3 * *",
+		"step":   "*/0 * * * *",
+		"month":  "0 0 1 foo *",
+	})
+
+	tests := []struct {
+		key   string
+		value string
+		cause string
+	}{
+		{"hour", "0 25 * * *", "invalid hour field '25': range 25-25 is not within 0-23"},
+		{"fields", "0 3 * *", "expected 5 fields but got 4"},
+		{"step", "*/0 * * * *", "invalid minute field '*/0': invalid step '0'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			_, err := cp.GetCron(test.key)
+			expected := newTypeConversionErrorWithCause(test.key, test.value, "*conf.CronSchedule", errors.New(test.cause))
+			AssertEquals(t, expected.Error(), err.Error(), "cp.GetCron error")
+		})
+	}
+
+	_, err := cp.GetCron("month")
+	var conversionErr *TypeConversionError
+	AssertEquals(t, true, errors.As(err, &conversionErr), "errors.As TypeConversionError")
+}
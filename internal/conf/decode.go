@@ -0,0 +1,239 @@
+package conf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Decode populates the exported fields of the struct target points to
+// with the values of the given ConfigProvider. The key of a field is
+// taken from its `conf:"key"` tag and defaults to the lowercased field
+// name. With `conf:"key,required"` a missing key results in a
+// KeyNotFoundError, otherwise the field is left untouched. Nested structs
+// are decoded with their key as prefix (e.g. 'server.port') and fields
+// tagged with `conf:"-"` are skipped. Defaults are given like for
+// UnmarshalConfig (e.g. `conf:"port,default=8080"`)
+func Decode(cp ConfigProvider, target interface{}) error {
+	value, err := structTarget(target)
+	if err != nil {
+		return err
+	}
+
+	d := decoder{tagName: "conf"}
+	return d.decodeStruct(cp, "", value)
+}
+
+// UnmarshalConfig populates the exported fields of the struct dst points
+// to like Decode but takes the keys from `config:"key"` tags. Fields are
+// required unless tagged with `config:"key,omitempty"` which leaves them
+// at their zero value if the key is missing. Instead of stopping at the
+// first problem all missing keys and invalid values are reported
+// together as an UnmarshalError. A missing key can be filled from the
+// tag with `config:"key,default=value"` which has to be the last option
+func UnmarshalConfig(provider ConfigProvider, dst interface{}) error {
+	value, err := structTarget(dst)
+	if err != nil {
+		return err
+	}
+
+	d := decoder{tagName: "config", requiredByDefault: true, collect: true}
+	if err := d.decodeStruct(provider, "", value); err != nil {
+		return err
+	}
+	if len(d.errs) > 0 {
+		return NewUnmarshalError(d.errs)
+	}
+
+	return nil
+}
+
+// UnmarshalError collects all errors that occurred while populating a
+// struct with UnmarshalConfig
+type UnmarshalError struct {
+	Errors  []error
+	message string
+}
+
+func NewUnmarshalError(errs []error) *UnmarshalError {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return &UnmarshalError{
+		Errors:  errs,
+		message: fmt.Sprintf("config could not be unmarshaled: %s", strings.Join(messages, "; ")),
+	}
+}
+
+func (e *UnmarshalError) Error() string {
+	return e.message
+}
+
+func (e *UnmarshalError) Unwrap() []error {
+	return e.Errors
+}
+
+func structTarget(target interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New("decode target has to be a non-nil pointer to a struct")
+	}
+
+	return value.Elem(), nil
+}
+
+// decoder holds the tag conventions of Decode and UnmarshalConfig. With
+// collect set field errors are gathered in errs instead of returned
+type decoder struct {
+	tagName           string
+	requiredByDefault bool
+	collect           bool
+	errs              []error
+}
+
+func (d *decoder) decodeStruct(cp ConfigProvider, prefix string, value reflect.Value) error {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := parseFieldTag(field, d.tagName)
+		if tag.skip {
+			continue
+		}
+
+		key := joinKey(prefix, tag.key)
+		fieldValue := value.Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			if err := d.decodeStruct(cp, key, fieldValue); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Errors other than a missing key (e.g. an unreadable file) are
+		// returned right away even when collecting as they are not
+		// specific to the field
+		has, err := cp.HasErr(key)
+		if err != nil {
+			return err
+		}
+
+		// Defaults are parsed with the options of cp so they behave like
+		// the same value in the config
+		source := cp
+		if !has && tag.hasDefault {
+			source = NewInMemoryConfigProvider(map[string]string{key: tag.defaultValue}, withOptions(optionsOf(cp)))
+			has = true
+		}
+
+		if !has {
+			if tag.required || (d.requiredByDefault && !tag.omitEmpty) {
+				if err := d.fail(NewKeyNotFoundError(key)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := decodeField(source, key, fieldValue); err != nil {
+			if err := d.fail(err); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fail records err if errors are collected or returns it otherwise
+func (d *decoder) fail(err error) error {
+	if !d.collect {
+		return err
+	}
+
+	d.errs = append(d.errs, err)
+	return nil
+}
+
+func decodeField(cp ConfigProvider, key string, value reflect.Value) error {
+	var decoded interface{}
+	var err error
+
+	switch {
+	case value.Type() == durationType:
+		decoded, err = cp.GetDuration(key)
+	case value.Type() == reflect.TypeOf(time.Time{}):
+		decoded, err = cp.GetTime(key)
+	case value.Kind() == reflect.String:
+		decoded, err = cp.GetString(key)
+	case value.Kind() == reflect.Bool:
+		decoded, err = cp.GetBool(key)
+	case value.Kind() == reflect.Int:
+		decoded, err = cp.GetInt(key)
+	case value.Kind() == reflect.Int64:
+		decoded, err = cp.GetInt64(key)
+	case value.Kind() == reflect.Uint:
+		decoded, err = cp.GetUint(key)
+	case value.Kind() == reflect.Float64:
+		decoded, err = cp.GetFloat(key)
+	case value.Type() == reflect.TypeOf([]string{}):
+		decoded, err = cp.GetStringSlice(key)
+	default:
+		return fmt.Errorf("field for key '%s' has the unsupported type '%s'", key, value.Type())
+	}
+	if err != nil {
+		return err
+	}
+
+	value.Set(reflect.ValueOf(decoded).Convert(value.Type()))
+	return nil
+}
+
+type fieldTag struct {
+	key          string
+	required     bool
+	omitEmpty    bool
+	skip         bool
+	hasDefault   bool
+	defaultValue string
+}
+
+func parseFieldTag(field reflect.StructField, name string) fieldTag {
+	tag, ok := field.Tag.Lookup(name)
+	if tag == "-" {
+		return fieldTag{skip: true}
+	}
+
+	parts := strings.Split(tag, ",")
+	result := fieldTag{key: parts[0]}
+	if !ok || result.key == "" {
+		result.key = strings.ToLower(field.Name)
+	}
+	for i, option := range parts[1:] {
+		if strings.HasPrefix(option, "default=") {
+			// The default is the remainder of the tag so it may contain
+			// commas (e.g. for slices)
+			result.hasDefault = true
+			result.defaultValue = strings.TrimPrefix(strings.Join(parts[i+1:], ","), "default=")
+			break
+		}
+
+		switch option {
+		case "required":
+			result.required = true
+		case "omitempty":
+			result.omitEmpty = true
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,218 @@
+package conf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+type ServerConfig struct {
+	Host    string
+	Port    int
+	Timeout time.Duration
+}
+
+type AppConfig struct {
+	Server   ServerConfig
+	Debug    bool     `conf:"debug"`
+	Ratio    float64  `conf:"sampling.ratio"`
+	Origins  []string `conf:"cors.origins"`
+	Ignored  string   `conf:"-"`
+	Required string   `conf:"api.key,required"`
+	internal string
+}
+
+func TestDecode(t *testing.T) {
+	cp := NewJSONConfigProviderFromReader(strings.NewReader(`{
+		"server": {"host": "localhost", "port": 8080, "timeout": "30s"},
+		"debug": true,
+		"sampling": {"ratio": 0.25},
+		"cors": {"origins": ["https://a.com", "https://b.com"]},
+		"ignored": "value",
+		"api": {"key": "secret"}
+	}`))
+
+	var config AppConfig
+	err := Decode(cp, &config)
+	AssertEquals(t, nil, err, "Decode error")
+
+	expected := AppConfig{
+		Server: ServerConfig{
+			Host:    "localhost",
+			Port:    8080,
+			Timeout: 30 * time.Second,
+		},
+		Debug:    true,
+		Ratio:    0.25,
+		Origins:  []string{"https://a.com", "https://b.com"},
+		Required: "secret",
+	}
+	AssertEquals(t, expected, config, "decoded config")
+}
+
+func TestDecodeServerConfig(t *testing.T) {
+	cp := NewJSONConfigProviderFromReader(strings.NewReader(`{"host": "db", "port": 5432}`))
+
+	config := ServerConfig{Timeout: time.Minute}
+	err := Decode(cp, &config)
+	AssertEquals(t, nil, err, "Decode error")
+	AssertEquals(t, ServerConfig{Host: "db", Port: 5432, Timeout: time.Minute}, config, "decoded config")
+}
+
+func TestDecodeErrors(t *testing.T) {
+	cp := NewJSONConfigProviderFromReader(strings.NewReader(`{"server": {"port": "http"}}`))
+
+	var config AppConfig
+	err := Decode(cp, &config)
+	AssertEquals(t, NewTypeConversionError("server.port", "http", "int"), err, "Decode error")
+
+	cp = NewJSONConfigProviderFromReader(strings.NewReader(`{"server": {"port": 80}}`))
+	err = Decode(cp, &config)
+	AssertEquals(t, NewKeyNotFoundError("api.key"), err, "Decode error")
+
+	err = Decode(cp, config)
+	AssertNotEquals(t, nil, err, "Decode error")
+}
+
+func TestDecodeUnreadableFile(t *testing.T) {
+	cp := NewFileConfigProvider("/nonexistent/x.properties")
+	_, expectedErr := cp.GetString("server.port")
+	var unknownErr *UnknownError
+	AssertEquals(t, true, errors.As(expectedErr, &unknownErr), "errors.As UnknownError")
+
+	var config AppConfig
+	AssertEquals(t, expectedErr, Decode(cp, &config), "Decode error")
+	AssertEquals(t, AppConfig{}, config, "config")
+
+	var defaults DefaultsConfig
+	AssertEquals(t, expectedErr, UnmarshalConfig(cp, &defaults), "UnmarshalConfig error with defaults")
+	AssertEquals(t, DefaultsConfig{}, defaults, "defaults are not applied")
+
+	var service ServiceConfig
+	AssertEquals(t, expectedErr, UnmarshalConfig(cp, &service), "UnmarshalConfig error")
+}
+
+type DatabaseConfig struct {
+	Host     string        `config:"host"`
+	Port     int           `config:"port"`
+	User     string        `config:"user"`
+	Password string        `config:"password,omitempty"`
+	Timeout  time.Duration `config:"timeout,omitempty"`
+}
+
+type ServiceConfig struct {
+	Database DatabaseConfig `config:"database"`
+	Debug    bool           `config:"debug,omitempty"`
+	Name     string         `config:"name"`
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"database.host": "localhost",
+		"database.port": "5432",
+		"database.user": "solvent",
+		"name":          "solvent",
+	})
+
+	var config ServiceConfig
+	err := UnmarshalConfig(cp, &config)
+	AssertEquals(t, nil, err, "UnmarshalConfig error")
+	AssertEquals(t, ServiceConfig{
+		Database: DatabaseConfig{Host: "localhost", Port: 5432, User: "solvent"},
+		Name:     "solvent",
+	}, config, "UnmarshalConfig value")
+}
+
+func TestUnmarshalConfigErrors(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"database.host": "localhost",
+		"database.port": "postgres",
+		"debug":         "maybe",
+	})
+
+	var config ServiceConfig
+	err := UnmarshalConfig(cp, &config)
+	AssertEquals(t, NewUnmarshalError([]error{
+		NewTypeConversionError("database.port", "postgres", "int"),
+		NewKeyNotFoundError("database.user"),
+		NewTypeConversionError("debug", "maybe", "bool"),
+		NewKeyNotFoundError("name"),
+	}), err, "UnmarshalConfig error")
+
+	var notFound *KeyNotFoundError
+	AssertEquals(t, true, errors.As(err, &notFound), "errors.As KeyNotFoundError")
+	AssertEquals(t, "database.user", notFound.Key, "notFound.Key")
+
+	err = UnmarshalConfig(cp, config)
+	AssertEquals(t, "decode target has to be a non-nil pointer to a struct", err.Error(), "UnmarshalConfig non-pointer error")
+}
+
+type DefaultsConfig struct {
+	Host    string        `config:"host,default=localhost"`
+	Port    int           `config:"port,default=8080"`
+	Workers uint          `config:"workers,default=4"`
+	Limit   int64         `config:"limit,default=9007199254740993"`
+	Ratio   float64       `config:"ratio,default=0.25"`
+	Debug   bool          `config:"debug,default=true"`
+	Timeout time.Duration `config:"timeout,default=5s"`
+	Origins []string      `config:"origins,omitempty,default=a.com, b.com"`
+	Empty   string        `config:"empty,default="`
+}
+
+func TestUnmarshalConfigDefaults(t *testing.T) {
+	var config DefaultsConfig
+	err := UnmarshalConfig(NewInMemoryConfigProvider(map[string]string{"port": "9090"}), &config)
+	AssertEquals(t, nil, err, "UnmarshalConfig error")
+	AssertEquals(t, DefaultsConfig{
+		Host:    "localhost",
+		Port:    9090,
+		Workers: 4,
+		Limit:   9007199254740993,
+		Ratio:   0.25,
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Origins: []string{"a.com", "b.com"},
+	}, config, "UnmarshalConfig value")
+}
+
+func TestUnmarshalConfigDefaultsUseProviderOptions(t *testing.T) {
+	type config struct {
+		Origins []string `config:"origins,default=a.com;b.com"`
+		Debug   bool     `config:"debug,default=yes"`
+	}
+	opts := []Option{WithSeparator(";"), WithLenientBool()}
+
+	providers := map[string]ConfigProvider{
+		"memory": NewInMemoryConfigProvider(nil, opts...),
+		"chain":  NewChainConfigProvider([]ConfigProvider{NewEnvConfigProvider("SOLVENT_TEST_", opts...)}),
+		"prefix": NewPrefixConfigProvider("app.", NewInMemoryConfigProvider(nil, opts...)),
+	}
+	for name, cp := range providers {
+		t.Run(name, func(t *testing.T) {
+			var value config
+			AssertEquals(t, nil, UnmarshalConfig(cp, &value), "UnmarshalConfig error")
+			AssertEquals(t, config{Origins: []string{"a.com", "b.com"}, Debug: true}, value, "UnmarshalConfig value")
+		})
+	}
+
+	var strict struct {
+		Port int `config:"port,default=0x1F90"`
+	}
+	err := UnmarshalConfig(NewInMemoryConfigProvider(nil, WithStrictDecimal()), &strict)
+	AssertEquals(t, NewUnmarshalError([]error{
+		NewTypeConversionError("port", "0x1F90", "int"),
+	}), err, "UnmarshalConfig error")
+}
+
+func TestUnmarshalConfigInvalidDefault(t *testing.T) {
+	var config struct {
+		Port int `config:"port,default=http"`
+	}
+	err := UnmarshalConfig(NewInMemoryConfigProvider(nil), &config)
+	AssertEquals(t, NewUnmarshalError([]error{
+		NewTypeConversionError("port", "http", "int"),
+	}), err, "UnmarshalConfig error")
+}
@@ -0,0 +1,410 @@
+package conf
+
+import (
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetStringOrDefault is like GetString but returns defaultValue if the
+// key does not exist
+func (g typedGetters) GetStringOrDefault(key, defaultValue string) (string, error) {
+	return orDefault(g.getString, key, defaultValue)
+}
+
+// GetFloatOrDefault is like GetFloat but returns defaultValue if the
+// key does not exist. Values that are not numbers still result in a
+// TypeConversionError
+func (g typedGetters) GetFloatOrDefault(key string, defaultValue float64) (float64, error) {
+	return orDefault(g.GetFloat, key, defaultValue)
+}
+
+// GetBoolOrDefault is like GetBool but returns defaultValue if the key
+// does not exist. Values other than the accepted bool spellings still
+// result in a TypeConversionError
+func (g typedGetters) GetBoolOrDefault(key string, defaultValue bool) (bool, error) {
+	return orDefault(g.GetBool, key, defaultValue)
+}
+
+// GetIntOrDefault is like GetInt but returns defaultValue if the key
+// does not exist. Values that are not integers or overflow int still
+// fail
+func (g typedGetters) GetIntOrDefault(key string, defaultValue int) (int, error) {
+	return orDefault(g.GetInt, key, defaultValue)
+}
+
+// GetInt64OrDefault is like GetInt64 but returns defaultValue if the
+// key does not exist. Values that are not integers or overflow int64
+// still fail
+func (g typedGetters) GetInt64OrDefault(key string, defaultValue int64) (int64, error) {
+	return orDefault(g.GetInt64, key, defaultValue)
+}
+
+// GetUintOrDefault is like GetUint but returns defaultValue if the key
+// does not exist. Negative values still fail
+func (g typedGetters) GetUintOrDefault(key string, defaultValue uint) (uint, error) {
+	return orDefault(g.GetUint, key, defaultValue)
+}
+
+// GetDurationOrDefault is like GetDuration but returns defaultValue if
+// the key does not exist. Values like '5 minutes' still fail instead of
+// falling back
+func (g typedGetters) GetDurationOrDefault(key string, defaultValue time.Duration) (time.Duration, error) {
+	return orDefault(g.GetDuration, key, defaultValue)
+}
+
+// GetTimeOrDefault is like GetTime but returns defaultValue if the key
+// does not exist. Values matching none of the time layouts still fail
+func (g typedGetters) GetTimeOrDefault(key string, defaultValue time.Time) (time.Time, error) {
+	return orDefault(g.GetTime, key, defaultValue)
+}
+
+// GetStringSliceOrDefault is like GetStringSlice but returns
+// defaultValue if the key does not exist. An empty value is an empty
+// slice and not missing
+func (g typedGetters) GetStringSliceOrDefault(key string, defaultValue []string) ([]string, error) {
+	return orDefault(g.GetStringSlice, key, defaultValue)
+}
+
+// GetIntSliceOrDefault is like GetIntSlice but returns defaultValue if
+// the key does not exist. A single invalid element still fails
+func (g typedGetters) GetIntSliceOrDefault(key string, defaultValue []int) ([]int, error) {
+	return orDefault(g.GetIntSlice, key, defaultValue)
+}
+
+// GetFloatSliceOrDefault is like GetFloatSlice but returns defaultValue
+// if the key does not exist. A single invalid element still fails
+func (g typedGetters) GetFloatSliceOrDefault(key string, defaultValue []float64) ([]float64, error) {
+	return orDefault(g.GetFloatSlice, key, defaultValue)
+}
+
+// GetURLOrDefault is like GetURL but returns defaultValue if the key
+// does not exist. URLs with a scheme other than the allowed ones still
+// fail
+func (g typedGetters) GetURLOrDefault(key string, defaultValue *url.URL, schemes ...string) (*url.URL, error) {
+	value, err := g.GetURL(key, schemes...)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetBytesOrDefault is like GetBytes but returns defaultValue if the
+// key does not exist. Values that are not validly encoded still fail
+func (g typedGetters) GetBytesOrDefault(key string, defaultValue []byte, encoding ...Encoding) ([]byte, error) {
+	value, err := g.GetBytes(key, encoding...)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetIPOrDefault is like GetIP but returns defaultValue if the key does
+// not exist. Malformed addresses still fail
+func (g typedGetters) GetIPOrDefault(key string, defaultValue net.IP) (net.IP, error) {
+	return orDefault(g.GetIP, key, defaultValue)
+}
+
+// GetCIDROrDefault is like GetCIDR but returns defaultValue if the key
+// does not exist. Malformed networks still fail
+func (g typedGetters) GetCIDROrDefault(key string, defaultValue *net.IPNet) (*net.IPNet, error) {
+	return orDefault(g.GetCIDR, key, defaultValue)
+}
+
+// GetTemplateOrDefault is like GetTemplate but returns defaultValue if
+// the key does not exist. Templates that do not parse still fail
+func (g typedGetters) GetTemplateOrDefault(key string, defaultValue *template.Template, funcs ...template.FuncMap) (*template.Template, error) {
+	value, err := g.GetTemplate(key, funcs...)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetMailAddressOrDefault is like GetMailAddress but returns
+// defaultValue if the key does not exist. Malformed addresses still
+// fail
+func (g typedGetters) GetMailAddressOrDefault(key string, defaultValue *mail.Address) (*mail.Address, error) {
+	return orDefault(g.GetMailAddress, key, defaultValue)
+}
+
+// GetMailAddressListOrDefault is like GetMailAddressList but returns
+// defaultValue if the key does not exist. A single malformed address
+// still fails
+func (g typedGetters) GetMailAddressListOrDefault(key string, defaultValue []*mail.Address) ([]*mail.Address, error) {
+	return orDefault(g.GetMailAddressList, key, defaultValue)
+}
+
+// GetPathOrDefault is like GetPath but returns defaultValue if the key
+// does not exist. With WithExistingPaths a path that does not exist
+// still fails
+func (g typedGetters) GetPathOrDefault(key string, defaultValue string) (string, error) {
+	return orDefault(g.GetPath, key, defaultValue)
+}
+
+// GetUUIDOrDefault is like GetUUID but returns defaultValue if the key
+// does not exist. Malformed identifiers still fail
+func (g typedGetters) GetUUIDOrDefault(key string, defaultValue uuid.UUID) (uuid.UUID, error) {
+	return orDefault(g.GetUUID, key, defaultValue)
+}
+
+// GetRegexpOrDefault is like GetRegexp but returns defaultValue if the
+// key does not exist. Patterns that do not compile still fail
+func (g typedGetters) GetRegexpOrDefault(key string, defaultValue *regexp.Regexp) (*regexp.Regexp, error) {
+	return orDefault(g.GetRegexp, key, defaultValue)
+}
+
+// GetSizeOrDefault is like GetSize but returns defaultValue if the key
+// does not exist. Values with an unknown unit still fail
+func (g typedGetters) GetSizeOrDefault(key string, defaultValue int64) (int64, error) {
+	return orDefault(g.GetSize, key, defaultValue)
+}
+
+// GetPortOrDefault is like GetPort but returns defaultValue if the key
+// does not exist. Ports outside the allowed range still fail
+func (g typedGetters) GetPortOrDefault(key string, defaultValue int) (int, error) {
+	return orDefault(g.GetPort, key, defaultValue)
+}
+
+// GetEnumOrDefault is like GetEnum but returns defaultValue if the key
+// does not exist. Values that are not allowed still result in an
+// InvalidEnumError
+func (g typedGetters) GetEnumOrDefault(key string, defaultValue string, allowed ...string) (string, error) {
+	value, err := g.GetEnum(key, allowed...)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetLogLevelOrDefault is like GetLogLevel but returns defaultValue if
+// the key does not exist. Unknown levels still fail
+func (g typedGetters) GetLogLevelOrDefault(key string, defaultValue slog.Level) (slog.Level, error) {
+	return orDefault(g.GetLogLevel, key, defaultValue)
+}
+
+// GetLocationOrDefault is like GetLocation but returns defaultValue if
+// the key does not exist. Unknown time zones still fail
+func (g typedGetters) GetLocationOrDefault(key string, defaultValue *time.Location) (*time.Location, error) {
+	return orDefault(g.GetLocation, key, defaultValue)
+}
+
+// GetHostPortOrDefault is like GetHostPort but returns defaultHost and
+// defaultPort if the key does not exist. Values without a port or with
+// one outside the allowed range still fail
+func (g typedGetters) GetHostPortOrDefault(key, defaultHost string, defaultPort int) (string, int, error) {
+	host, port, err := g.GetHostPort(key)
+	if isKeyNotFound(err) {
+		return defaultHost, defaultPort, nil
+	}
+
+	return host, port, err
+}
+
+// GetStringOrDefault is like GetString but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetStringOrDefault(key, defaultValue string) (string, error) {
+	return orDefault(cp.GetString, key, defaultValue)
+}
+
+// GetFloatOrDefault is like GetFloat but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetFloatOrDefault(key string, defaultValue float64) (float64, error) {
+	return orDefault(cp.GetFloat, key, defaultValue)
+}
+
+// GetBoolOrDefault is like GetBool but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetBoolOrDefault(key string, defaultValue bool) (bool, error) {
+	return orDefault(cp.GetBool, key, defaultValue)
+}
+
+// GetIntOrDefault is like GetInt but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetIntOrDefault(key string, defaultValue int) (int, error) {
+	return orDefault(cp.GetInt, key, defaultValue)
+}
+
+// GetInt64OrDefault is like GetInt64 but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetInt64OrDefault(key string, defaultValue int64) (int64, error) {
+	return orDefault(cp.GetInt64, key, defaultValue)
+}
+
+// GetUintOrDefault is like GetUint but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetUintOrDefault(key string, defaultValue uint) (uint, error) {
+	return orDefault(cp.GetUint, key, defaultValue)
+}
+
+// GetDurationOrDefault is like GetDuration but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetDurationOrDefault(key string, defaultValue time.Duration) (time.Duration, error) {
+	return orDefault(cp.GetDuration, key, defaultValue)
+}
+
+// GetTimeOrDefault is like GetTime but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetTimeOrDefault(key string, defaultValue time.Time) (time.Time, error) {
+	return orDefault(cp.GetTime, key, defaultValue)
+}
+
+// GetStringSliceOrDefault is like GetStringSlice but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetStringSliceOrDefault(key string, defaultValue []string) ([]string, error) {
+	return orDefault(cp.GetStringSlice, key, defaultValue)
+}
+
+// GetIntSliceOrDefault is like GetIntSlice but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetIntSliceOrDefault(key string, defaultValue []int) ([]int, error) {
+	return orDefault(cp.GetIntSlice, key, defaultValue)
+}
+
+// GetFloatSliceOrDefault is like GetFloatSlice but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetFloatSliceOrDefault(key string, defaultValue []float64) ([]float64, error) {
+	return orDefault(cp.GetFloatSlice, key, defaultValue)
+}
+
+// GetURLOrDefault is like GetURL but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetURLOrDefault(key string, defaultValue *url.URL, schemes ...string) (*url.URL, error) {
+	value, err := cp.GetURL(key, schemes...)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetBytesOrDefault is like GetBytes but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetBytesOrDefault(key string, defaultValue []byte, encoding ...Encoding) ([]byte, error) {
+	value, err := cp.GetBytes(key, encoding...)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetIPOrDefault is like GetIP but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetIPOrDefault(key string, defaultValue net.IP) (net.IP, error) {
+	return orDefault(cp.GetIP, key, defaultValue)
+}
+
+// GetCIDROrDefault is like GetCIDR but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetCIDROrDefault(key string, defaultValue *net.IPNet) (*net.IPNet, error) {
+	return orDefault(cp.GetCIDR, key, defaultValue)
+}
+
+// GetTemplateOrDefault is like GetTemplate but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetTemplateOrDefault(key string, defaultValue *template.Template, funcs ...template.FuncMap) (*template.Template, error) {
+	value, err := cp.GetTemplate(key, funcs...)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetMailAddressOrDefault is like GetMailAddress but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetMailAddressOrDefault(key string, defaultValue *mail.Address) (*mail.Address, error) {
+	return orDefault(cp.GetMailAddress, key, defaultValue)
+}
+
+// GetMailAddressListOrDefault is like GetMailAddressList but returns
+// defaultValue if no provider has the key
+func (cp *ChainConfigProvider) GetMailAddressListOrDefault(key string, defaultValue []*mail.Address) ([]*mail.Address, error) {
+	return orDefault(cp.GetMailAddressList, key, defaultValue)
+}
+
+// GetPathOrDefault is like GetPath but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetPathOrDefault(key string, defaultValue string) (string, error) {
+	return orDefault(cp.GetPath, key, defaultValue)
+}
+
+// GetUUIDOrDefault is like GetUUID but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetUUIDOrDefault(key string, defaultValue uuid.UUID) (uuid.UUID, error) {
+	return orDefault(cp.GetUUID, key, defaultValue)
+}
+
+// GetRegexpOrDefault is like GetRegexp but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetRegexpOrDefault(key string, defaultValue *regexp.Regexp) (*regexp.Regexp, error) {
+	return orDefault(cp.GetRegexp, key, defaultValue)
+}
+
+// GetSizeOrDefault is like GetSize but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetSizeOrDefault(key string, defaultValue int64) (int64, error) {
+	return orDefault(cp.GetSize, key, defaultValue)
+}
+
+// GetPortOrDefault is like GetPort but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetPortOrDefault(key string, defaultValue int) (int, error) {
+	return orDefault(cp.GetPort, key, defaultValue)
+}
+
+// GetEnumOrDefault is like GetEnum but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetEnumOrDefault(key string, defaultValue string, allowed ...string) (string, error) {
+	value, err := cp.GetEnum(key, allowed...)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetLogLevelOrDefault is like GetLogLevel but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetLogLevelOrDefault(key string, defaultValue slog.Level) (slog.Level, error) {
+	return orDefault(cp.GetLogLevel, key, defaultValue)
+}
+
+// GetLocationOrDefault is like GetLocation but returns defaultValue if
+// no provider has the key
+func (cp *ChainConfigProvider) GetLocationOrDefault(key string, defaultValue *time.Location) (*time.Location, error) {
+	return orDefault(cp.GetLocation, key, defaultValue)
+}
+
+// GetHostPortOrDefault is like GetHostPort but returns defaultHost and
+// defaultPort if no provider has the key
+func (cp *ChainConfigProvider) GetHostPortOrDefault(key, defaultHost string, defaultPort int) (string, int, error) {
+	host, port, err := cp.GetHostPort(key)
+	if isKeyNotFound(err) {
+		return defaultHost, defaultPort, nil
+	}
+
+	return host, port, err
+}
+
+// orDefault calls get and replaces a KeyNotFoundError with the given
+// default. All other errors are returned unchanged
+func orDefault[T any](get func(key string) (T, error), key string, defaultValue T) (T, error) {
+	value, err := get(key)
+	if isKeyNotFound(err) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
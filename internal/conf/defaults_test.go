@@ -0,0 +1,153 @@
+package conf
+
+import (
+	"errors"
+	"net/mail"
+	"net/url"
+	"testing"
+	"text/template"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestOrDefault(t *testing.T) {
+	file := NewFileConfigProvider(testFile)
+	chain := NewChainConfigProvider([]ConfigProvider{file})
+
+	for name, cp := range map[string]interface {
+		GetStringOrDefault(key, defaultValue string) (string, error)
+		GetFloatOrDefault(key string, defaultValue float64) (float64, error)
+		GetBoolOrDefault(key string, defaultValue bool) (bool, error)
+	}{"file": file, "chain": chain} {
+		t.Run(name, func(t *testing.T) {
+			s, err := cp.GetStringOrDefault("string", "default")
+			AssertEquals(t, nil, err, "cp.GetStringOrDefault error")
+			AssertEquals(t, "value", s, "cp.GetStringOrDefault present")
+
+			s, err = cp.GetStringOrDefault("missing", "default")
+			AssertEquals(t, nil, err, "cp.GetStringOrDefault error")
+			AssertEquals(t, "default", s, "cp.GetStringOrDefault missing")
+
+			f, err := cp.GetFloatOrDefault("float", 1.5)
+			AssertEquals(t, nil, err, "cp.GetFloatOrDefault error")
+			AssertEquals(t, 3.14, f, "cp.GetFloatOrDefault present")
+
+			f, err = cp.GetFloatOrDefault("missing", 1.5)
+			AssertEquals(t, nil, err, "cp.GetFloatOrDefault error")
+			AssertEquals(t, 1.5, f, "cp.GetFloatOrDefault missing")
+
+			b, err := cp.GetBoolOrDefault("missing", true)
+			AssertEquals(t, nil, err, "cp.GetBoolOrDefault error")
+			AssertEquals(t, true, b, "cp.GetBoolOrDefault missing")
+
+			_, err = cp.GetBoolOrDefault("string", true)
+			var conversionErr *TypeConversionError
+			AssertEquals(t, true, errors.As(err, &conversionErr), "errors.As TypeConversionError")
+		})
+	}
+}
+
+func TestOrDefaultOnlyForMissingKeys(t *testing.T) {
+	file := NewFileConfigProvider(testFile)
+	chain := NewChainConfigProvider([]ConfigProvider{file})
+
+	port, err := file.GetPortOrDefault("missing", 8080)
+	AssertEquals(t, nil, err, "file.GetPortOrDefault error")
+	AssertEquals(t, 8080, port, "file.GetPortOrDefault missing")
+
+	timeout, err := chain.GetDurationOrDefault("missing", time.Minute)
+	AssertEquals(t, nil, err, "chain.GetDurationOrDefault error")
+	AssertEquals(t, time.Minute, timeout, "chain.GetDurationOrDefault missing")
+
+	value, err := file.GetIntOrDefault("int", 7)
+	AssertEquals(t, nil, err, "file.GetIntOrDefault error")
+	AssertEquals(t, 42, value, "file.GetIntOrDefault present")
+
+	_, err = file.GetIntOrDefault("string", 7)
+	AssertEquals(t, NewTypeConversionError("string", "value", "int"), err, "file.GetIntOrDefault garbage")
+
+	_, err = chain.GetDurationOrDefault("string", time.Minute)
+	var chainErr *ChainError
+	AssertEquals(t, true, errors.As(err, &chainErr), "errors.As ChainError")
+	var conversionErr *TypeConversionError
+	AssertEquals(t, true, errors.As(err, &conversionErr), "errors.As TypeConversionError")
+	AssertEquals(t, "time.Duration", conversionErr.Type, "conversionErr.Type")
+}
+
+func TestChainOrDefaultMissingThenInvalid(t *testing.T) {
+	chain := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{}),
+		NewInMemoryConfigProvider(map[string]string{"port": "notanumber"}),
+	})
+
+	_, err := chain.GetIntOrDefault("port", 8080)
+	AssertEquals(t, NewChainError("port", []error{NewTypeConversionError("port", "notanumber", "int")}), err, "chain.GetIntOrDefault error")
+
+	value, err := chain.GetIntOrDefault("missing", 8080)
+	AssertEquals(t, nil, err, "chain.GetIntOrDefault error")
+	AssertEquals(t, 8080, value, "chain.GetIntOrDefault missing")
+}
+
+func TestOrDefaultVariadicGetters(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"endpoint": "ftp://example.com",
+		"storage":  "postgress",
+		"cache":    "cache.internal:70000",
+		"greeting": "{{.Name",
+		"admins":   "a@example.com, broken",
+		"secret":   "not base64!",
+	})
+	chain := NewChainConfigProvider([]ConfigProvider{cp})
+
+	for name, provider := range map[string]interface {
+		GetURLOrDefault(key string, defaultValue *url.URL, schemes ...string) (*url.URL, error)
+		GetBytesOrDefault(key string, defaultValue []byte, encoding ...Encoding) ([]byte, error)
+		GetEnumOrDefault(key string, defaultValue string, allowed ...string) (string, error)
+		GetHostPortOrDefault(key, defaultHost string, defaultPort int) (string, int, error)
+		GetTemplateOrDefault(key string, defaultValue *template.Template, funcs ...template.FuncMap) (*template.Template, error)
+		GetMailAddressListOrDefault(key string, defaultValue []*mail.Address) ([]*mail.Address, error)
+	}{"memory": cp, "chain": chain} {
+		t.Run(name, func(t *testing.T) {
+			defaultURL := &url.URL{Scheme: "https", Host: "example.com"}
+			u, err := provider.GetURLOrDefault("missing", defaultURL, "https")
+			AssertEquals(t, nil, err, "GetURLOrDefault error")
+			AssertEquals(t, defaultURL, u, "GetURLOrDefault missing")
+			_, err = provider.GetURLOrDefault("endpoint", defaultURL, "https")
+			AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "GetURLOrDefault invalid")
+
+			b, err := provider.GetBytesOrDefault("missing", []byte("default"))
+			AssertEquals(t, nil, err, "GetBytesOrDefault error")
+			AssertEquals(t, []byte("default"), b, "GetBytesOrDefault missing")
+			_, err = provider.GetBytesOrDefault("secret", nil)
+			AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "GetBytesOrDefault invalid")
+
+			s, err := provider.GetEnumOrDefault("missing", "memory", "postgres", "memory")
+			AssertEquals(t, nil, err, "GetEnumOrDefault error")
+			AssertEquals(t, "memory", s, "GetEnumOrDefault missing")
+			_, err = provider.GetEnumOrDefault("storage", "memory", "postgres", "memory")
+			var enumErr *InvalidEnumError
+			AssertEquals(t, true, errors.As(err, &enumErr), "GetEnumOrDefault invalid")
+
+			host, port, err := provider.GetHostPortOrDefault("missing", "localhost", 6379)
+			AssertEquals(t, nil, err, "GetHostPortOrDefault error")
+			AssertEquals(t, "localhost", host, "GetHostPortOrDefault host")
+			AssertEquals(t, 6379, port, "GetHostPortOrDefault port")
+			_, _, err = provider.GetHostPortOrDefault("cache", "localhost", 6379)
+			AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "GetHostPortOrDefault invalid")
+
+			defaultTemplate := template.Must(template.New("default").Parse("hello"))
+			tmpl, err := provider.GetTemplateOrDefault("missing", defaultTemplate)
+			AssertEquals(t, nil, err, "GetTemplateOrDefault error")
+			AssertEquals(t, defaultTemplate, tmpl, "GetTemplateOrDefault missing")
+			_, err = provider.GetTemplateOrDefault("greeting", defaultTemplate)
+			AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "GetTemplateOrDefault invalid")
+
+			addresses, err := provider.GetMailAddressListOrDefault("missing", []*mail.Address{})
+			AssertEquals(t, nil, err, "GetMailAddressListOrDefault error")
+			AssertEquals(t, []*mail.Address{}, addresses, "GetMailAddressListOrDefault missing")
+			_, err = provider.GetMailAddressListOrDefault("admins", nil)
+			AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "GetMailAddressListOrDefault invalid")
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package conf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// document lazily parses a structured config document (e.g. JSON or
+// TOML) into a flat store. Documents read from a file are parsed again
+// on the next access after the modification time of the file changed
+type document struct {
+	open    func() (io.ReadCloser, error)
+	modTime func() time.Time
+	parse   func(r io.Reader) (map[string]string, error)
+	store   map[string]string
+	loaded  time.Time
+	mutex   sync.RWMutex
+
+	// The modification time is only checked once per checkInterval so
+	// lookups do not stat the file every time
+	checkInterval time.Duration
+	checked       time.Time
+	lastModTime   time.Time
+	checkMutex    sync.Mutex
+}
+
+func newFileDocument(path string, checkInterval time.Duration, parse func(r io.Reader) (map[string]string, error)) *document {
+	return &document{
+		checkInterval: checkInterval,
+		open: func() (io.ReadCloser, error) {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, &UnknownError{
+					err:     err,
+					message: fmt.Sprintf("could not open file with path '%s'", path),
+				}
+			}
+
+			return file, nil
+		},
+		modTime: func() time.Time {
+			info, err := os.Stat(path)
+			if err != nil {
+				return time.Time{}
+			}
+
+			return info.ModTime()
+		},
+		parse: parse,
+	}
+}
+
+// newOptionalFileDocument is like newFileDocument but treats a missing
+// file as an empty document
+func newOptionalFileDocument(path string, checkInterval time.Duration, parse func(r io.Reader) (map[string]string, error)) *document {
+	d := newFileDocument(path, checkInterval, parse)
+	open := d.open
+	d.open = func() (io.ReadCloser, error) {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+
+		return open()
+	}
+
+	return d
+}
+
+// newReaderDocument buffers the content of r on the first load so a
+// failed parse does not leave later loads with an exhausted reader
+func newReaderDocument(r io.Reader, parse func(r io.Reader) (map[string]string, error)) *document {
+	var data []byte
+	var readErr error
+	read := false
+
+	return &document{
+		open: func() (io.ReadCloser, error) {
+			if !read {
+				data, readErr = io.ReadAll(r)
+				read = true
+			}
+			if readErr != nil {
+				return nil, &UnknownError{
+					err:     readErr,
+					message: "could not read config",
+				}
+			}
+
+			return io.NopCloser(bytes.NewReader(data)), nil
+		},
+		parse: parse,
+	}
+}
+
+func (d *document) getString(key string, o options) (string, error) {
+	store, err := d.loadStore()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := store[o.normalizeKey(key)]
+	if !ok {
+		return "", NewKeyNotFoundError(key)
+	}
+
+	return value, nil
+}
+
+// keys returns the sorted flattened keys or nil if the document cannot
+// be read
+func (d *document) keys() []string {
+	store, err := d.loadStore()
+	if err != nil {
+		return nil
+	}
+
+	return sortedKeys(store)
+}
+
+// all returns a copy of the flattened values
+func (d *document) all() (map[string]string, error) {
+	store, err := d.loadStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return copyMap(store), nil
+}
+
+// loadStore lazily initializes the store and reloads it if the
+// modification time of the underlying file changed. It is safe for
+// concurrent use
+func (d *document) loadStore() (map[string]string, error) {
+	modTime := d.currentModTime()
+
+	d.mutex.RLock()
+	store := d.store
+	loaded := d.loaded
+	d.mutex.RUnlock()
+	if store != nil && loaded.Equal(modTime) {
+		return store, nil
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.store == nil || !d.loaded.Equal(modTime) {
+		m, err := d.load()
+		if err != nil {
+			return nil, err
+		}
+		d.store = m
+		d.loaded = modTime
+	}
+
+	return d.store, nil
+}
+
+// currentModTime returns the modification time of the underlying file
+// as of the last check or checks it again if checkInterval passed since
+func (d *document) currentModTime() time.Time {
+	if d.modTime == nil {
+		return time.Time{}
+	}
+
+	d.checkMutex.Lock()
+	defer d.checkMutex.Unlock()
+	now := time.Now()
+	if d.checked.IsZero() || now.Sub(d.checked) >= d.checkInterval {
+		d.lastModTime = d.modTime()
+		d.checked = now
+	}
+
+	return d.lastModTime
+}
+
+func (d *document) load() (map[string]string, error) {
+	r, err := d.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return d.parse(r)
+}
+
+// flattenValue stores value under dot-delimited keys. Arrays of scalars
+// are joined with the given separator so GetStringSlice can split them,
+// other arrays are flattened with the element index as key
+func flattenValue(store map[string]string, key string, value interface{}, separator string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for childKey, child := range v {
+			flattenValue(store, joinKey(key, childKey), child, separator)
+		}
+	case []map[string]interface{}:
+		for i, child := range v {
+			flattenValue(store, joinKey(key, fmt.Sprint(i)), child, separator)
+		}
+	case []interface{}:
+		elements := make([]string, 0, len(v))
+		for _, child := range v {
+			if isScalar(child) {
+				elements = append(elements, formatScalar(child))
+			}
+		}
+		if len(elements) == len(v) {
+			store[key] = strings.Join(elements, separator)
+			return
+		}
+		// Mixed arrays are indexed element by element so their scalars
+		// are kept as well
+		for i, child := range v {
+			flattenValue(store, joinKey(key, fmt.Sprint(i)), child, separator)
+		}
+	case nil:
+		// null values are treated as absent
+	default:
+		store[key] = formatScalar(v)
+	}
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []map[string]interface{}, []interface{}, nil:
+		return false
+	default:
+		return true
+	}
+}
+
+// formatScalar formats times as RFC 3339 so GetTime can parse them with
+// the default layout
+func formatScalar(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// normalizeKeys lowercases all keys of store if keys are
+// case-insensitive and reports keys that only differ in case
+func normalizeKeys(store map[string]string, o options) (map[string]string, error) {
+	if !o.caseInsensitiveKeys {
+		return store, nil
+	}
+
+	normalized := make(map[string]string, len(store))
+	originalKeys := map[string]string{}
+	for _, key := range sortedKeys(store) {
+		lower := strings.ToLower(key)
+		if earlier, ok := originalKeys[lower]; ok {
+			return nil, newKeyCollisionError(0, key, key, earlier)
+		}
+		originalKeys[lower] = key
+		normalized[lower] = store[key]
+	}
+
+	return normalized, nil
+}
+
+// parsingErrorAt returns a ParsingError for the given 1-based line of
+// data or one with the message of err if the line does not exist. err
+// is kept as the cause so the message of the decoder is not lost
+func parsingErrorAt(data string, lineNumber int, err error) *ParsingError {
+	lines := strings.Split(data, "\n")
+	if lineNumber < 1 || lineNumber > len(lines) {
+		e := NewParsingError(err.Error())
+		e.err = err
+		return e
+	}
+
+	e := NewParsingErrorAt(lineNumber, strings.TrimSpace(lines[lineNumber-1]))
+	e.err = err
+	e.message += ": " + err.Error()
+
+	return e
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
@@ -0,0 +1,186 @@
+package conf
+
+import (
+	"io"
+	"strings"
+)
+
+// DotenvConfigProvider reads config values from a .env file as used by
+// Docker Compose. Values of a '.local' file next to it (e.g. '.env.local'
+// for '.env') take precedence if that file exists
+type DotenvConfigProvider struct {
+	typedGetters
+	base  *document
+	local *document
+}
+
+// NewDotenvConfigProvider creates a new DotenvConfigProvider for the
+// .env file at the given path and its optional '.local' override and
+// returns the error of reading or parsing them. The files are read again
+// on the next access after their modification time changed. Paths are
+// resolved like in NewFileConfigProvider
+func NewDotenvConfigProvider(path string, opts ...Option) (*DotenvConfigProvider, error) {
+	realPath := callerRelativePath(path)
+
+	cp := &DotenvConfigProvider{}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+	cp.base = newFileDocument(realPath, cp.options.reloadCheckInterval, cp.parse)
+	cp.local = newOptionalFileDocument(realPath+".local", cp.options.reloadCheckInterval, cp.parse)
+	if _, err := cp.All(); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+func (cp *DotenvConfigProvider) GetString(key string) (string, error) {
+	value, err := cp.local.getString(key, cp.options)
+	if !isKeyNotFound(err) {
+		return value, err
+	}
+
+	return cp.base.getString(key, cp.options)
+}
+
+// Keys returns the sorted keys of the .env file and its override or nil
+// if one of them cannot be read
+func (cp *DotenvConfigProvider) Keys() []string {
+	if _, err := cp.base.loadStore(); err != nil {
+		return nil
+	}
+	if _, err := cp.local.loadStore(); err != nil {
+		return nil
+	}
+
+	keys := map[string]string{}
+	for _, key := range append(cp.base.keys(), cp.local.keys()...) {
+		keys[key] = ""
+	}
+
+	return sortedKeys(keys)
+}
+
+// All returns a copy of the values of the .env file merged with the
+// ones of its override
+func (cp *DotenvConfigProvider) All() (map[string]string, error) {
+	result, err := cp.base.all()
+	if err != nil {
+		return nil, err
+	}
+	local, err := cp.local.all()
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range local {
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+func (cp *DotenvConfigProvider) parse(r io.Reader) (map[string]string, error) {
+	store, err := initMapFromDotenv(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeKeys(store, cp.options)
+}
+
+// initMapFromDotenv parses KEY=VALUE lines with an optional 'export'
+// prefix. Single quoted values are taken literally, double quoted values
+// support escape sequences and may span multiple lines. Unquoted values
+// end at an inline comment starting with whitespace followed by '#'
+func initMapFromDotenv(r io.Reader) (map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &UnknownError{
+			err:     err,
+			message: "could not read .env config",
+		}
+	}
+
+	content := strings.TrimPrefix(string(data), "\ufeff")
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	store := map[string]string{}
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		lineNumber := i + 1
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		assignment := line
+		if strings.HasPrefix(assignment, "export ") || strings.HasPrefix(assignment, "export\t") {
+			assignment = strings.TrimSpace(assignment[len("export"):])
+		}
+
+		tokens := strings.SplitN(assignment, "=", 2)
+		key := strings.TrimSpace(tokens[0])
+		if len(tokens) != 2 || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, NewParsingErrorAt(lineNumber, line)
+		}
+
+		rawValue := strings.TrimLeft(tokens[1], " \t")
+		if rawValue == "" || (rawValue[0] != '"' && rawValue[0] != '\'') {
+			store[key] = stripDotenvComment(rawValue)
+			continue
+		}
+
+		// Quoted values may continue on the following lines
+		quote := rawValue[0]
+		end := closingQuote(rawValue, quote)
+		for end < 0 && i+1 < len(lines) {
+			i++
+			rawValue += "\n" + lines[i]
+			end = closingQuote(rawValue, quote)
+		}
+		if end < 0 {
+			return nil, NewParsingErrorAt(lineNumber, line)
+		}
+		if rest := strings.TrimSpace(rawValue[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, NewParsingErrorAt(lineNumber, line)
+		}
+
+		value := rawValue[1:end]
+		if quote == '"' {
+			value = unescapeDotenv(value)
+		}
+		store[key] = value
+	}
+
+	return store, nil
+}
+
+// closingQuote returns the index of the quote closing the value starting
+// with an opening quote or -1. Double quotes can be escaped with '\'
+func closingQuote(value string, quote byte) int {
+	for i := 1; i < len(value); i++ {
+		if quote == '"' && value[i] == '\\' {
+			i++
+			continue
+		}
+		if value[i] == quote {
+			return i
+		}
+	}
+
+	return -1
+}
+
+var dotenvUnescaper = strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`, `\$`, "$")
+
+func unescapeDotenv(value string) string {
+	return dotenvUnescaper.Replace(value)
+}
+
+func stripDotenvComment(value string) string {
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimSpace(value[:i])
+		}
+	}
+
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,98 @@
+package conf
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func newDotenvProvider(t *testing.T, path string, opts ...Option) *DotenvConfigProvider {
+	t.Helper()
+
+	cp, err := NewDotenvConfigProvider(path, opts...)
+	AssertEquals(t, nil, err, "NewDotenvConfigProvider error")
+
+	return cp
+}
+
+func TestDotenvGetString(t *testing.T) {
+	cp := newDotenvProvider(t, "testdata/dotenv/.env")
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"DB_HOST", "localhost"},
+		{"DB_PORT", "5432"},
+		{"DB_PASSWORD", "pa$$ word # not a comment"},
+		{"GREETING", "Hello\n\"World\""},
+		{"CERT", "-----BEGIN-----\nabc\n-----END-----"},
+		{"URL", "http://example.com/#anchor"},
+		{"EMPTY", ""},
+		{"OVERRIDDEN", "local"},
+		{"LOCAL_ONLY", "1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := cp.GetString(test.key)
+			AssertEquals(t, nil, err, "cp.GetString error")
+			AssertEquals(t, test.expected, value, "cp.GetString value")
+		})
+	}
+
+	_, err := cp.GetString("export DB_HOST")
+	AssertEquals(t, NewKeyNotFoundError("export DB_HOST"), err, "cp.GetString error")
+
+	port, err := cp.GetPort("DB_PORT")
+	AssertEquals(t, nil, err, "cp.GetPort error")
+	AssertEquals(t, 5432, port, "cp.GetPort value")
+}
+
+func TestDotenvKeys(t *testing.T) {
+	cp := newDotenvProvider(t, "testdata/dotenv/.env")
+	expected := []string{"CERT", "DB_HOST", "DB_PASSWORD", "DB_PORT", "EMPTY", "GREETING", "LOCAL_ONLY", "OVERRIDDEN", "URL"}
+	AssertEquals(t, expected, cp.Keys(), "cp.Keys")
+
+	cp = newDotenvProvider(t, "testdata/dotenv-nolocal/.env")
+	AssertEquals(t, []string{"DB_HOST"}, cp.Keys(), "cp.Keys without override")
+}
+
+func TestDotenvMalformed(t *testing.T) {
+	tests := []struct {
+		content    string
+		lineNumber int
+		line       string
+	}{
+		{"VALID=1\nINVALID\n", 2, "INVALID"},
+		{"KEY WITH SPACE=1\n", 1, "KEY WITH SPACE=1"},
+		{"VALID=1\n\nUNTERMINATED=\"abc\nDEF=1\n", 3, "UNTERMINATED=\"abc"},
+		{"TRAILING='abc' def\n", 1, "TRAILING='abc' def"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".env")
+			writeFile(t, path, test.content)
+			_, err := NewDotenvConfigProvider(path)
+			AssertEquals(t, NewParsingErrorAt(test.lineNumber, test.line), err, "cp.GetString error")
+		})
+	}
+}
+
+func TestDotenvMissingFile(t *testing.T) {
+	cp, err := NewDotenvConfigProvider(filepath.Join(t.TempDir(), ".env"))
+	_, ok := err.(*UnknownError)
+	AssertEquals(t, true, ok, "NewDotenvConfigProvider UnknownError")
+	AssertEquals(t, (*DotenvConfigProvider)(nil), cp, "cp")
+}
+
+func TestDotenvMalformedOverride(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".env"), "VALID=1\n")
+	writeFile(t, filepath.Join(dir, ".env.local"), "INVALID\n")
+
+	_, err := NewDotenvConfigProvider(filepath.Join(dir, ".env"))
+	AssertEquals(t, NewParsingErrorAt(1, "INVALID"), err, "NewDotenvConfigProvider error")
+}
@@ -0,0 +1,85 @@
+package conf
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// EnvConfigProvider reads config values from the environment of the
+// current process so they can override file based config in a
+// ChainConfigProvider
+type EnvConfigProvider struct {
+	typedGetters
+	prefix string
+}
+
+// NewEnvConfigProvider creates a new EnvConfigProvider that prepends
+// the given prefix (e.g. 'SOLVENT_') to every looked up key. A missing
+// trailing underscore is added so 'SOLVENT' maps 'server.port' to
+// 'SOLVENT_SERVER_PORT'
+func NewEnvConfigProvider(prefix string, opts ...Option) *EnvConfigProvider {
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+
+	cp := &EnvConfigProvider{
+		prefix: prefix,
+	}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+
+	return cp
+}
+
+func (cp *EnvConfigProvider) GetString(key string) (string, error) {
+	value, ok := os.LookupEnv(cp.variableName(key))
+	if !ok {
+		return "", NewKeyNotFoundError(key)
+	}
+
+	return value, nil
+}
+
+// Keys returns the sorted keys of all environment variables starting
+// with the prefix. As the mapping to variable names is not reversible
+// underscores are mapped back to dots (e.g. 'postgres.host')
+func (cp *EnvConfigProvider) Keys() []string {
+	keys := []string{}
+	for _, variable := range os.Environ() {
+		name := strings.SplitN(variable, "=", 2)[0]
+		if !strings.HasPrefix(name, cp.prefix) || name == cp.prefix {
+			continue
+		}
+
+		key := strings.TrimPrefix(name, cp.prefix)
+		keys = append(keys, strings.ToLower(strings.ReplaceAll(key, "_", ".")))
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// All returns the values of all environment variables starting with the
+// prefix under the keys returned by Keys
+func (cp *EnvConfigProvider) All() (map[string]string, error) {
+	result := map[string]string{}
+	for _, variable := range os.Environ() {
+		tokens := strings.SplitN(variable, "=", 2)
+		if !strings.HasPrefix(tokens[0], cp.prefix) || tokens[0] == cp.prefix || len(tokens) != 2 {
+			continue
+		}
+
+		key := strings.TrimPrefix(tokens[0], cp.prefix)
+		result[strings.ToLower(strings.ReplaceAll(key, "_", "."))] = tokens[1]
+	}
+
+	return result, nil
+}
+
+// variableName maps a config key like 'postgres.host' to the name of
+// the environment variable holding its value (e.g. 'SOLVENT_POSTGRES_HOST')
+func (cp *EnvConfigProvider) variableName(key string) string {
+	return cp.prefix + strings.ToUpper(envKeyReplacer.Replace(key))
+}
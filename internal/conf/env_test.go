@@ -0,0 +1,109 @@
+package conf
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestEnvGetString(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_POSTGRES_HOST", "db")
+	t.Setenv("SOLVENT_TEST_LOG_LEVEL", "debug")
+	cp := NewEnvConfigProvider("SOLVENT_TEST_")
+
+	value, err := cp.GetString("postgres.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "db", value, "cp.GetString value")
+
+	value, err = cp.GetString("log-level")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "debug", value, "cp.GetString value")
+
+	_, err = cp.GetString("postgres.port")
+	AssertEquals(t, NewKeyNotFoundError("postgres.port"), err, "cp.GetString error")
+}
+
+func TestEnvGetInt(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_NEGATIVE", "-13")
+	t.Setenv("SOLVENT_TEST_ZERO", "0")
+	t.Setenv("SOLVENT_TEST_OVERFLOW", "9223372036854775808")
+	cp := NewEnvConfigProvider("SOLVENT_TEST_")
+
+	value, err := cp.GetInt("negative")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, -13, value, "cp.GetInt value")
+
+	value, err = cp.GetInt("zero")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 0, value, "cp.GetInt value")
+
+	_, err = cp.GetInt("overflow")
+	AssertEquals(t, NewTypeConversionError("overflow", "9223372036854775808", "int"), err, "cp.GetInt error")
+}
+
+func TestEnvOverridesFile(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_INT", "7")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_TEST_"),
+		NewFileConfigProvider(testFile),
+	})
+
+	value, err := cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 7, value, "cp.GetInt value")
+}
+
+func TestEnvKeys(t *testing.T) {
+	t.Setenv("SOLVENT_KEYS_TEST_POSTGRES_HOST", "db")
+	t.Setenv("SOLVENT_KEYS_TEST_PORT", "5432")
+	cp := NewEnvConfigProvider("SOLVENT_KEYS_TEST_")
+
+	AssertEquals(t, []string{"port", "postgres.host"}, cp.Keys(), "cp.Keys")
+}
+
+func TestEnvEmptyValue(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_DB_HOST", "")
+	t.Setenv("SOLVENT_TEST_DB_TIMEOUT", "5s")
+	cp := NewEnvConfigProvider("SOLVENT_TEST_")
+
+	value, err := cp.GetString("db_host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "", value, "cp.GetString value")
+	AssertEquals(t, true, cp.Has("db_host"), "cp.Has")
+
+	_, err = cp.GetString("db_user")
+	AssertEquals(t, NewKeyNotFoundError("db_user"), err, "cp.GetString error")
+	AssertEquals(t, false, cp.Has("db_user"), "cp.Has")
+
+	timeout, err := cp.GetDuration("db_timeout")
+	AssertEquals(t, nil, err, "cp.GetDuration error")
+	AssertEquals(t, 5*time.Second, timeout, "cp.GetDuration value")
+}
+
+func TestEnvPrefixWithoutUnderscore(t *testing.T) {
+	t.Setenv("SOLVENTPREFIX_SERVER_PORT", "8080")
+	t.Setenv("SOLVENTPREFIX_FEATURE_BETA", "true")
+	t.Setenv("SOLVENTPREFIX_RATIO", "")
+	cp := NewEnvConfigProvider("SOLVENTPREFIX")
+
+	port, err := cp.GetInt("server.port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 8080, port, "cp.GetInt value")
+
+	beta, err := cp.GetBool("feature.beta")
+	AssertEquals(t, nil, err, "cp.GetBool error")
+	AssertEquals(t, true, beta, "cp.GetBool value")
+
+	AssertEquals(t, []string{"feature.beta", "ratio", "server.port"}, cp.Keys(), "cp.Keys")
+
+	_, err = cp.GetFloat("ratio")
+	AssertEquals(t, NewTypeConversionError("ratio", "", "float64"), err, "cp.GetFloat error for empty value")
+	_, err = cp.GetBool("missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "cp.GetBool error")
+
+	unprefixed := NewEnvConfigProvider("")
+	value, err := unprefixed.GetString("solventprefix.server.port")
+	AssertEquals(t, nil, err, "unprefixed.GetString error")
+	AssertEquals(t, "8080", value, "unprefixed.GetString value")
+}
@@ -0,0 +1,172 @@
+package conf
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// UnsupportedTypeError indicates that Get was called with a type it
+// cannot convert config values into
+type UnsupportedTypeError struct {
+	Type    string
+	message string
+}
+
+func NewUnsupportedTypeError(typ string) *UnsupportedTypeError {
+	return &UnsupportedTypeError{
+		Type:    typ,
+		message: "type '" + typ + "' is not supported",
+	}
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return e.message
+}
+
+// Get returns the value of the given key converted to T. Types with a
+// dedicated getter (e.g. int or time.Duration) are delegated to it, the
+// remaining int and uint widths are range checked results of GetInt64
+// and GetUint, float32 is parsed from GetString and []byte holds the raw
+// bytes of GetString (use GetBytes to decode them). Any other type
+// results in an UnsupportedTypeError
+func Get[T any](cp ConfigProvider, key string) (T, error) {
+	var value T
+	var err error
+
+	switch v := any(&value).(type) {
+	case *string:
+		*v, err = cp.GetString(key)
+	case *bool:
+		*v, err = cp.GetBool(key)
+	case *int:
+		*v, err = cp.GetInt(key)
+	case *int8:
+		var i int64
+		i, err = getSizedInt(cp, key, 8, "int8")
+		*v = int8(i)
+	case *int16:
+		var i int64
+		i, err = getSizedInt(cp, key, 16, "int16")
+		*v = int16(i)
+	case *int32:
+		var i int64
+		i, err = getSizedInt(cp, key, 32, "int32")
+		*v = int32(i)
+	case *int64:
+		*v, err = cp.GetInt64(key)
+	case *uint:
+		*v, err = cp.GetUint(key)
+	case *uint8:
+		var u uint64
+		u, err = getSizedUint(cp, key, 8, "uint8")
+		*v = uint8(u)
+	case *uint16:
+		var u uint64
+		u, err = getSizedUint(cp, key, 16, "uint16")
+		*v = uint16(u)
+	case *uint32:
+		var u uint64
+		u, err = getSizedUint(cp, key, 32, "uint32")
+		*v = uint32(u)
+	case *uint64:
+		*v, err = getSizedUint(cp, key, 64, "uint64")
+	case *float32:
+		var f float64
+		f, err = getFloat32(cp, key)
+		*v = float32(f)
+	case *float64:
+		*v, err = cp.GetFloat(key)
+	case *time.Duration:
+		*v, err = cp.GetDuration(key)
+	case *time.Time:
+		*v, err = cp.GetTime(key)
+	case *[]string:
+		*v, err = cp.GetStringSlice(key)
+	case *[]byte:
+		var s string
+		s, err = cp.GetString(key)
+		*v = []byte(s)
+	default:
+		err = NewUnsupportedTypeError(reflect.TypeOf(&value).Elem().String())
+	}
+
+	return value, err
+}
+
+// getSizedInt parses values with GetInt64 so options like
+// WithStrictDecimal apply and checks that they fit into bitSize bits
+func getSizedInt(cp ConfigProvider, key string, bitSize int, typ string) (int64, error) {
+	value, err := cp.GetInt64(key)
+	if err != nil {
+		return 0, sizedConversionError(key, typ, err)
+	}
+
+	limit := int64(1) << (bitSize - 1)
+	if value < -limit || value > limit-1 {
+		return 0, sizedRangeError(cp, key, typ)
+	}
+
+	return value, nil
+}
+
+// getSizedUint parses values with GetUint so options like
+// WithStrictDecimal apply and checks that they fit into bitSize bits.
+// uint64 values are limited to the size of uint on the platform
+func getSizedUint(cp ConfigProvider, key string, bitSize int, typ string) (uint64, error) {
+	u, err := cp.GetUint(key)
+	if err != nil {
+		return 0, sizedConversionError(key, typ, err)
+	}
+
+	value := uint64(u)
+	if bitSize < 64 && value > uint64(1)<<bitSize-1 {
+		return 0, sizedRangeError(cp, key, typ)
+	}
+
+	return value, nil
+}
+
+// sizedConversionError reports conversion errors of the wider getter
+// with the requested type
+func sizedConversionError(key, typ string, err error) error {
+	if convErr, ok := err.(*TypeConversionError); ok {
+		return NewTypeConversionError(key, convErr.Value, typ)
+	}
+
+	return err
+}
+
+func sizedRangeError(cp ConfigProvider, key, typ string) error {
+	stringValue, err := cp.GetString(key)
+	if err != nil {
+		return err
+	}
+
+	return NewTypeConversionError(key, stringValue, typ)
+}
+
+func getFloat32(cp ConfigProvider, key string) (float64, error) {
+	stringValue, err := cp.GetString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseFloat(stringValue, 32)
+	if err != nil {
+		return 0, NewTypeConversionError(key, stringValue, "float32")
+	}
+
+	return value, nil
+}
+
+// MustGet is like Get but panics with the returned error. It is meant
+// for config read during initialization where a failure is fatal
+func MustGet[T any](cp ConfigProvider, key string) T {
+	value, err := Get[T](cp, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
@@ -0,0 +1,205 @@
+package conf
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+var genericTestProvider = NewInMemoryConfigProvider(map[string]string{
+	"string":   "value",
+	"bool":     "true",
+	"int":      "42",
+	"int8":     "-128",
+	"int16":    "0x7FFF",
+	"int32":    "-2147483648",
+	"uint8":    "255",
+	"uint64":   "18446744073709551615",
+	"float32":  "1.5",
+	"float64":  "3.14",
+	"duration": "2h45m",
+	"time":     "2021-03-04T05:06:07Z",
+	"slice":    "a,b,c",
+	"bytes":    "aGVsbG8=",
+	"hex":      "deadbeef",
+	"overflow": "256",
+})
+
+func TestGet(t *testing.T) {
+	cp := genericTestProvider
+
+	s, err := Get[string](cp, "string")
+	AssertEquals(t, nil, err, "Get[string] error")
+	AssertEquals(t, "value", s, "Get[string] value")
+
+	b, err := Get[bool](cp, "bool")
+	AssertEquals(t, nil, err, "Get[bool] error")
+	AssertEquals(t, true, b, "Get[bool] value")
+
+	i, err := Get[int](cp, "int")
+	AssertEquals(t, nil, err, "Get[int] error")
+	AssertEquals(t, 42, i, "Get[int] value")
+
+	i8, err := Get[int8](cp, "int8")
+	AssertEquals(t, nil, err, "Get[int8] error")
+	AssertEquals(t, int8(math.MinInt8), i8, "Get[int8] value")
+
+	i16, err := Get[int16](cp, "int16")
+	AssertEquals(t, nil, err, "Get[int16] error")
+	AssertEquals(t, int16(math.MaxInt16), i16, "Get[int16] value")
+
+	i32, err := Get[int32](cp, "int32")
+	AssertEquals(t, nil, err, "Get[int32] error")
+	AssertEquals(t, int32(math.MinInt32), i32, "Get[int32] value")
+
+	i64, err := Get[int64](cp, "int")
+	AssertEquals(t, nil, err, "Get[int64] error")
+	AssertEquals(t, int64(42), i64, "Get[int64] value")
+
+	u, err := Get[uint](cp, "int")
+	AssertEquals(t, nil, err, "Get[uint] error")
+	AssertEquals(t, uint(42), u, "Get[uint] value")
+
+	u8, err := Get[uint8](cp, "uint8")
+	AssertEquals(t, nil, err, "Get[uint8] error")
+	AssertEquals(t, uint8(math.MaxUint8), u8, "Get[uint8] value")
+
+	u16, err := Get[uint16](cp, "int")
+	AssertEquals(t, nil, err, "Get[uint16] error")
+	AssertEquals(t, uint16(42), u16, "Get[uint16] value")
+
+	u32, err := Get[uint32](cp, "int")
+	AssertEquals(t, nil, err, "Get[uint32] error")
+	AssertEquals(t, uint32(42), u32, "Get[uint32] value")
+
+	u64, err := Get[uint64](cp, "uint64")
+	AssertEquals(t, nil, err, "Get[uint64] error")
+	AssertEquals(t, uint64(math.MaxUint64), u64, "Get[uint64] value")
+
+	f32, err := Get[float32](cp, "float32")
+	AssertEquals(t, nil, err, "Get[float32] error")
+	AssertEquals(t, float32(1.5), f32, "Get[float32] value")
+
+	f64, err := Get[float64](cp, "float64")
+	AssertEquals(t, nil, err, "Get[float64] error")
+	AssertEquals(t, 3.14, f64, "Get[float64] value")
+
+	d, err := Get[time.Duration](cp, "duration")
+	AssertEquals(t, nil, err, "Get[time.Duration] error")
+	AssertEquals(t, 2*time.Hour+45*time.Minute, d, "Get[time.Duration] value")
+
+	tm, err := Get[time.Time](cp, "time")
+	AssertEquals(t, nil, err, "Get[time.Time] error")
+	AssertEquals(t, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), tm, "Get[time.Time] value")
+
+	slice, err := Get[[]string](cp, "slice")
+	AssertEquals(t, nil, err, "Get[[]string] error")
+	AssertEquals(t, []string{"a", "b", "c"}, slice, "Get[[]string] value")
+
+	bytes, err := Get[[]byte](cp, "bytes")
+	AssertEquals(t, nil, err, "Get[[]byte] error")
+	AssertEquals(t, []byte("aGVsbG8="), bytes, "Get[[]byte] value")
+
+	bytes, err = Get[[]byte](cp, "hex")
+	AssertEquals(t, nil, err, "Get[[]byte] error")
+	AssertEquals(t, []byte("deadbeef"), bytes, "Get[[]byte] value")
+}
+
+func TestGetErrors(t *testing.T) {
+	cp := genericTestProvider
+
+	_, err := Get[uint8](cp, "overflow")
+	AssertEquals(t, NewTypeConversionError("overflow", "256", "uint8"), err, "Get[uint8] error")
+
+	_, err = Get[int8](cp, "string")
+	AssertEquals(t, NewTypeConversionError("string", "value", "int8"), err, "Get[int8] error")
+
+	_, err = Get[float32](cp, "missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "Get[float32] error")
+
+	_, err = Get[complex128](cp, "int")
+	AssertEquals(t, NewUnsupportedTypeError("complex128"), err, "Get[complex128] error")
+
+	_, err = Get[map[string]int](cp, "int")
+	AssertEquals(t, NewUnsupportedTypeError("map[string]int"), err, "Get[map[string]int] error")
+
+	_, err = Get[int8](cp, "uint8")
+	AssertEquals(t, NewTypeConversionError("uint8", "255", "int8"), err, "Get[int8] error")
+
+	_, err = Get[int32](NewInMemoryConfigProvider(map[string]string{"min": "-2147483649"}), "min")
+	AssertEquals(t, NewTypeConversionError("min", "-2147483649", "int32"), err, "Get[int32] error")
+}
+
+func TestGetStrictDecimal(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"hex":       "0x10",
+		"separated": "1_000",
+		"plain":     "100",
+	}, WithStrictDecimal())
+
+	_, err := Get[int8](cp, "hex")
+	AssertEquals(t, NewTypeConversionError("hex", "0x10", "int8"), err, "Get[int8] error")
+
+	_, err = Get[uint16](cp, "separated")
+	AssertEquals(t, NewTypeConversionError("separated", "1_000", "uint16"), err, "Get[uint16] error")
+
+	value, err := Get[int8](cp, "plain")
+	AssertEquals(t, nil, err, "Get[int8] error")
+	AssertEquals(t, int8(100), value, "Get[int8] value")
+}
+
+func assertMustGet[T any](t *testing.T, cp ConfigProvider, key string, expected T) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("MustGet[%T](%q) panicked with '%v'", expected, key, r)
+		}
+	}()
+
+	AssertEquals(t, expected, MustGet[T](cp, key), "MustGet value")
+}
+
+func assertMustGetPanics[T any](t *testing.T, cp ConfigProvider, key string, expected error) {
+	t.Helper()
+
+	defer func() {
+		AssertEquals(t, expected, recover(), "MustGet panic")
+	}()
+
+	MustGet[T](cp, key)
+}
+
+func TestMustGet(t *testing.T) {
+	cp := genericTestProvider
+
+	assertMustGet(t, cp, "string", "value")
+	assertMustGet(t, cp, "bool", true)
+	assertMustGet(t, cp, "int", 42)
+	assertMustGet(t, cp, "int8", int8(math.MinInt8))
+	assertMustGet(t, cp, "int16", int16(math.MaxInt16))
+	assertMustGet(t, cp, "int32", int32(math.MinInt32))
+	assertMustGet(t, cp, "int", int64(42))
+	assertMustGet(t, cp, "int", uint(42))
+	assertMustGet(t, cp, "uint8", uint8(math.MaxUint8))
+	assertMustGet(t, cp, "int", uint16(42))
+	assertMustGet(t, cp, "int", uint32(42))
+	assertMustGet(t, cp, "uint64", uint64(math.MaxUint64))
+	assertMustGet(t, cp, "float32", float32(1.5))
+	assertMustGet(t, cp, "float64", 3.14)
+	assertMustGet(t, cp, "duration", 2*time.Hour+45*time.Minute)
+	assertMustGet(t, cp, "time", time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))
+	assertMustGet(t, cp, "slice", []string{"a", "b", "c"})
+	assertMustGet(t, cp, "bytes", []byte("aGVsbG8="))
+}
+
+func TestMustGetPanics(t *testing.T) {
+	cp := genericTestProvider
+
+	assertMustGetPanics[int](t, cp, "missing", NewKeyNotFoundError("missing"))
+	assertMustGetPanics[bool](t, cp, "int", NewTypeConversionError("int", "42", "bool"))
+	assertMustGetPanics[uint8](t, cp, "overflow", NewTypeConversionError("overflow", "256", "uint8"))
+	assertMustGetPanics[complex64](t, cp, "int", NewUnsupportedTypeError("complex64"))
+}
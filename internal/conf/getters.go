@@ -0,0 +1,1093 @@
+package conf
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// typedGetters implements the typed getters of the ConfigProvider
+// interface on top of the plain string lookup of a provider
+type typedGetters struct {
+	getString func(key string) (string, error)
+	options   options
+	cache     *sync.Map
+
+	// baseDir is the directory GetPath resolves relative paths against.
+	// The current working directory is used if it is empty
+	baseDir string
+
+	// lookupString is an optional variant of getString that does not
+	// allocate an error for missing keys
+	lookupString func(key string) (string, bool)
+}
+
+func newTypedGetters(getString func(key string) (string, error), opts []Option) typedGetters {
+	return typedGetters{
+		getString: getString,
+		options:   newOptions(opts),
+		cache:     &sync.Map{},
+	}
+}
+
+func (g typedGetters) providerOptions() (options, bool) {
+	return g.options, true
+}
+
+type cacheKey struct {
+	typ string
+	key string
+}
+
+type cacheEntry struct {
+	raw   string
+	value interface{}
+}
+
+// cached returns the value previously parsed for the given key and type
+// as long as the raw value did not change since or calls parse otherwise
+func (g typedGetters) cached(typ, key, raw string, parse func() (interface{}, error)) (interface{}, error) {
+	k := cacheKey{typ: typ, key: key}
+	if entry, ok := g.cache.Load(k); ok && entry.(cacheEntry).raw == raw {
+		return entry.(cacheEntry).value, nil
+	}
+
+	value, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	g.cache.Store(k, cacheEntry{raw: raw, value: value})
+
+	return value, nil
+}
+
+// Has reports whether a value for the given key exists
+func (g typedGetters) Has(key string) bool {
+	_, err := g.getString(key)
+	return err == nil
+}
+
+// HasErr is like Has but distinguishes a missing key from a config that
+// cannot be read or parsed by returning the error of the latter
+func (g typedGetters) HasErr(key string) (bool, error) {
+	_, err := g.getString(key)
+	if isKeyNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (g typedGetters) GetFloat(key string) (float64, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseFloat(stringValue, 64)
+	if err != nil {
+		return value, NewTypeConversionError(key, stringValue, "float64")
+	}
+
+	return value, nil
+}
+
+func (g typedGetters) GetBool(key string) (bool, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return false, err
+	}
+
+	if g.options.lenientBools {
+		return parseLenientBool(key, stringValue)
+	}
+
+	value, err := strconv.ParseBool(stringValue)
+	if err != nil {
+		return value, NewTypeConversionError(key, stringValue, "bool")
+	}
+
+	return value, nil
+}
+
+// lenientBools are the tokens GetBool accepts with WithLenientBool in
+// addition to the ones of strconv.ParseBool
+var lenientBools = map[string]bool{
+	"yes": true, "no": false,
+	"y": true, "n": false,
+	"on": true, "off": false,
+	"enabled": true, "disabled": false,
+}
+
+var lenientBoolTokens = []string{
+	"true", "false", "t", "f", "1", "0",
+	"yes", "no", "y", "n", "on", "off", "enabled", "disabled",
+}
+
+func parseLenientBool(key, stringValue string) (bool, error) {
+	token := strings.ToLower(strings.TrimSpace(stringValue))
+	if value, ok := lenientBools[token]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.ParseBool(token)
+	if err != nil {
+		cause := fmt.Errorf("expected one of %s (ignoring case)", strings.Join(lenientBoolTokens, ", "))
+		return false, newTypeConversionErrorWithCause(key, stringValue, "bool", cause)
+	}
+
+	return value, nil
+}
+
+func (g typedGetters) GetInt(key string) (int, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := parseInt(strings.TrimSpace(stringValue), strconv.IntSize, g.options.strictDecimal)
+	if err != nil {
+		return 0, NewTypeConversionError(key, stringValue, "int")
+	}
+
+	return int(value), nil
+}
+
+func (g typedGetters) GetInt64(key string) (int64, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := parseInt(strings.TrimSpace(stringValue), 64, g.options.strictDecimal)
+	if err != nil {
+		return 0, NewTypeConversionError(key, stringValue, "int64")
+	}
+
+	return value, nil
+}
+
+func (g typedGetters) GetUint(key string) (uint, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := parseUint(strings.TrimSpace(stringValue), strconv.IntSize, g.options.strictDecimal)
+	if err != nil {
+		return 0, NewTypeConversionError(key, stringValue, "uint")
+	}
+
+	return uint(value), nil
+}
+
+// parseInt parses decimal values as well as values with a '0x', '0o'
+// or '0b' base prefix. Leading zeros without a prefix are still decimal
+// and underscores may separate digits (e.g. '1_000_000'). With
+// strictDecimal only plain decimal values are accepted
+func parseInt(value string, bitSize int, strictDecimal bool) (int64, error) {
+	if strictDecimal {
+		return strconv.ParseInt(value, 10, bitSize)
+	}
+	if hasBasePrefix(value) {
+		return strconv.ParseInt(value, 0, bitSize)
+	}
+
+	return strconv.ParseInt(stripDigitSeparators(value), 10, bitSize)
+}
+
+func parseUint(value string, bitSize int, strictDecimal bool) (uint64, error) {
+	if strictDecimal {
+		return strconv.ParseUint(value, 10, bitSize)
+	}
+	if hasBasePrefix(value) {
+		return strconv.ParseUint(value, 0, bitSize)
+	}
+
+	return strconv.ParseUint(stripDigitSeparators(value), 10, bitSize)
+}
+
+// stripDigitSeparators removes underscores between digits of a decimal
+// value. Values with leading, trailing or doubled underscores are
+// returned unchanged so parsing them fails
+func stripDigitSeparators(value string) string {
+	digits := strings.TrimLeft(value, "+-")
+	if !strings.Contains(digits, "_") ||
+		strings.HasPrefix(digits, "_") ||
+		strings.HasSuffix(digits, "_") ||
+		strings.Contains(digits, "__") {
+		return value
+	}
+
+	return strings.ReplaceAll(value, "_", "")
+}
+
+func hasBasePrefix(value string) bool {
+	value = strings.TrimLeft(value, "+-")
+	if len(value) < 2 || value[0] != '0' {
+		return false
+	}
+
+	switch value[1] {
+	case 'x', 'X', 'o', 'O', 'b', 'B':
+		return true
+	default:
+		return false
+	}
+}
+
+// GetDuration parses values in the format of time.ParseDuration (e.g.
+// '500ms' or '2h45m') and interprets bare numbers as seconds
+func (g typedGetters) GetDuration(key string) (time.Duration, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	// Bare seconds beyond the range of time.Duration would silently
+	// overflow when converted to nanoseconds
+	const maxSeconds = math.MaxInt64 / int64(time.Second)
+	trimmedValue := strings.TrimSpace(stringValue)
+	if seconds, err := strconv.ParseInt(trimmedValue, 10, 64); err == nil {
+		if seconds > maxSeconds || seconds < -maxSeconds {
+			return 0, NewTypeConversionError(key, stringValue, "time.Duration")
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if seconds, err := strconv.ParseFloat(trimmedValue, 64); err == nil {
+		if math.IsNaN(seconds) || math.Abs(seconds*float64(time.Second)) >= math.MaxInt64 {
+			return 0, NewTypeConversionError(key, stringValue, "time.Duration")
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	value, err := time.ParseDuration(trimmedValue)
+	if err != nil {
+		return 0, NewTypeConversionError(key, stringValue, "time.Duration")
+	}
+
+	return value, nil
+}
+
+// GetTime parses values with the RFC3339 layout followed by the layouts
+// registered with WithTimeLayouts and keeps the zone offset of the value
+func (g typedGetters) GetTime(key string) (time.Time, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	trimmedValue := strings.TrimSpace(stringValue)
+	for _, layout := range g.options.timeLayouts {
+		if value, err := time.Parse(layout, trimmedValue); err == nil {
+			return value, nil
+		}
+	}
+
+	err = fmt.Errorf("tried layouts %q", g.options.timeLayouts)
+	return time.Time{}, newTypeConversionErrorWithCause(key, stringValue, "time.Time", err)
+}
+
+// GetLocation loads values as time zones (e.g. 'Europe/Vienna', 'UTC'
+// or 'Local') and caches the result until the value changes
+func (g typedGetters) GetLocation(key string) (*time.Location, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := g.cached("timezone", key, stringValue, func() (interface{}, error) {
+		name := strings.TrimSpace(stringValue)
+		if name == "" {
+			return nil, newTypeConversionErrorWithCause(key, stringValue, "timezone", errors.New("time zone name is empty"))
+		}
+
+		value, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, newTypeConversionErrorWithCause(key, stringValue, "timezone", err)
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*time.Location), nil
+}
+
+// GetStringSlice splits values on the configured separator, trims the
+// whitespace around every element and drops empty elements
+func (g typedGetters) GetStringSlice(key string) ([]string, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitList(stringValue, g.options), nil
+}
+
+// GetIntSlice splits values like GetStringSlice and converts every
+// element to an int
+func (g typedGetters) GetIntSlice(key string) ([]int, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := splitList(stringValue, g.options)
+	values := make([]int, len(elements))
+	for i, element := range elements {
+		value, err := parseInt(element, strconv.IntSize, g.options.strictDecimal)
+		if err != nil {
+			return nil, newSliceElementError(key, stringValue, "[]int", i, element)
+		}
+		values[i] = int(value)
+	}
+
+	return values, nil
+}
+
+// GetFloatSlice splits values like GetStringSlice and converts every
+// element to a float64
+func (g typedGetters) GetFloatSlice(key string) ([]float64, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := splitList(stringValue, g.options)
+	values := make([]float64, len(elements))
+	for i, element := range elements {
+		value, err := strconv.ParseFloat(element, 64)
+		if err != nil {
+			return nil, newSliceElementError(key, stringValue, "[]float64", i, element)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+func newSliceElementError(key, value, typ string, index int, element string) *TypeConversionError {
+	err := fmt.Errorf("element %d '%s' is invalid", index, element)
+	return newTypeConversionErrorWithCause(key, value, typ, err)
+}
+
+// splitList splits the given value on the configured separator. Empty
+// elements are dropped so an empty value results in an empty slice
+func splitList(value string, o options) []string {
+	elements := []string{}
+	if strings.TrimSpace(value) == "" {
+		return elements
+	}
+
+	whitespace := strings.TrimSpace(o.separator) == ""
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(value); i++ {
+		if o.quotedElements && value[i] == '"' {
+			inQuotes = !inQuotes
+			continue
+		}
+		if inQuotes {
+			continue
+		}
+
+		if whitespace && isSpace(value[i]) {
+			elements = appendListElement(elements, value[start:i], o)
+			start = i + 1
+		} else if !whitespace && strings.HasPrefix(value[i:], o.separator) {
+			elements = appendListElement(elements, value[start:i], o)
+			start = i + len(o.separator)
+			i = start - 1
+		}
+	}
+	elements = appendListElement(elements, value[start:], o)
+
+	return elements
+}
+
+// appendListElement appends the trimmed element unless it is empty so
+// repeated or trailing separators don't produce empty entries. A quoted
+// empty element is kept
+func appendListElement(elements []string, element string, o options) []string {
+	element = strings.TrimSpace(element)
+	if element == "" {
+		return elements
+	}
+
+	if o.quotedElements && len(element) >= 2 && element[0] == '"' && element[len(element)-1] == '"' {
+		element = element[1 : len(element)-1]
+	}
+
+	return append(elements, element)
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// GetURL parses values as absolute URLs. If schemes are given the scheme
+// of the URL has to be one of them
+func (g typedGetters) GetURL(key string, schemes ...string) (*url.URL, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := url.Parse(strings.TrimSpace(stringValue))
+	if err != nil {
+		return nil, newTypeConversionErrorWithCause(key, stringValue, "*url.URL", err)
+	}
+
+	if value.Scheme == "" && g.options.relativeURLs {
+		return value, nil
+	}
+	if value.Scheme == "" || (value.Host == "" && isHostPort(value.String())) {
+		return nil, newTypeConversionErrorWithCause(key, stringValue, "*url.URL", errors.New("missing scheme"))
+	}
+	if value.Host == "" {
+		return nil, newTypeConversionErrorWithCause(key, stringValue, "*url.URL", errors.New("missing host"))
+	}
+	if len(schemes) > 0 && !containsFold(schemes, value.Scheme) {
+		err := fmt.Errorf("scheme '%s' is not one of %q", value.Scheme, schemes)
+		return nil, newTypeConversionErrorWithCause(key, stringValue, "*url.URL", err)
+	}
+
+	return value, nil
+}
+
+// isHostPort reports whether value is a bare 'host:port' which url.Parse
+// would otherwise mistake for a scheme with an opaque part
+func isHostPort(value string) bool {
+	_, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return false
+	}
+	_, err = strconv.ParseUint(port, 10, 16)
+
+	return err == nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Encoding tells GetBytes how a value is encoded
+type Encoding int
+
+const (
+	// Base64 accepts standard as well as URL-safe base64
+	Base64 Encoding = iota + 1
+	Hex
+	// Raw returns the bytes of the value as they are
+	Raw
+)
+
+// GetBytes decodes values encoded with the given encoding. Without an
+// encoding standard base64, URL-safe base64 and hex are tried in order
+func (g typedGetters) GetBytes(key string, encoding ...Encoding) ([]byte, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoders []func(string) ([]byte, error)
+	if len(encoding) == 0 {
+		decoders = []func(string) ([]byte, error){
+			base64.StdEncoding.DecodeString,
+			base64.URLEncoding.DecodeString,
+			hex.DecodeString,
+		}
+	} else {
+		switch encoding[0] {
+		case Base64:
+			decoders = []func(string) ([]byte, error){
+				base64.StdEncoding.DecodeString,
+				base64.URLEncoding.DecodeString,
+			}
+		case Hex:
+			decoders = []func(string) ([]byte, error){hex.DecodeString}
+		case Raw:
+			return []byte(stringValue), nil
+		}
+	}
+
+	trimmedValue := strings.TrimSpace(stringValue)
+	for _, decode := range decoders {
+		if value, err := decode(trimmedValue); err == nil {
+			return value, nil
+		}
+	}
+
+	return nil, NewTypeConversionError(key, stringValue, "[]byte")
+}
+
+// GetIP parses IPv4 as well as IPv6 addresses
+func (g typedGetters) GetIP(key string) (net.IP, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value := net.ParseIP(strings.TrimSpace(stringValue))
+	if value == nil {
+		return nil, NewTypeConversionError(key, stringValue, "net.IP")
+	}
+
+	return value, nil
+}
+
+// GetCIDR parses values in CIDR notation and returns the masked network
+// (e.g. '10.0.0.5/8' results in 10.0.0.0/8)
+func (g typedGetters) GetCIDR(key string) (*net.IPNet, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	_, value, err := net.ParseCIDR(strings.TrimSpace(stringValue))
+	if err != nil {
+		return nil, NewTypeConversionError(key, stringValue, "*net.IPNet")
+	}
+
+	return value, nil
+}
+
+// GetTemplate parses values as text/template templates named after the
+// key. The given function maps are registered before parsing. Templates
+// without function maps are cached until the value changes and must not
+// be modified by the caller
+func (g typedGetters) GetTemplate(key string, funcs ...template.FuncMap) (*template.Template, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	parse := func() (interface{}, error) {
+		tmpl := template.New(key)
+		for _, f := range funcs {
+			tmpl = tmpl.Funcs(f)
+		}
+
+		value, err := tmpl.Parse(stringValue)
+		if err != nil {
+			return nil, newTypeConversionErrorWithCause(key, stringValue, "*template.Template", err)
+		}
+
+		return value, nil
+	}
+
+	// Function maps are not comparable and may change between calls so
+	// templates using them are parsed every time
+	var value interface{}
+	if len(funcs) > 0 {
+		value, err = parse()
+	} else {
+		value, err = g.cached("*template.Template", key, stringValue, parse)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*template.Template), nil
+}
+
+// GetMailAddress parses values as RFC 5322 addresses like
+// 'ops@example.com' or 'SRE <sre@example.com>'
+func (g typedGetters) GetMailAddress(key string) (*mail.Address, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := mail.ParseAddress(strings.TrimSpace(stringValue))
+	if err != nil {
+		return nil, newTypeConversionErrorWithCause(key, stringValue, "*mail.Address", err)
+	}
+
+	return value, nil
+}
+
+// GetMailAddressList splits values like GetStringSlice and parses every
+// element as RFC 5322 address. Separators within quoted display names
+// (e.g. '"Doe, Jane" <jane@example.com>') do not split the value
+func (g typedGetters) GetMailAddressList(key string) ([]*mail.Address, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	o := g.options
+	o.quotedElements = true
+	elements := splitList(stringValue, o)
+	values := make([]*mail.Address, len(elements))
+	for i, element := range elements {
+		value, err := mail.ParseAddress(element)
+		if err != nil {
+			return nil, newSliceElementError(key, stringValue, "[]*mail.Address", i, element)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// GetPath returns absolute paths cleaned and joins relative ones onto
+// the directory of the config file if the provider is file based or
+// onto the current working directory otherwise. With WithExistingPaths
+// a PathNotFoundError is returned if nothing exists at the path
+func (g typedGetters) GetPath(key string) (string, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return "", err
+	}
+
+	path := workingDirRelativePath(stringValue)
+	if g.baseDir != "" && !filepath.IsAbs(stringValue) {
+		path = filepath.Join(g.baseDir, stringValue)
+	}
+
+	if g.options.existingPaths {
+		if _, err := os.Stat(path); err != nil {
+			return "", NewPathNotFoundError(key, path, err)
+		}
+	}
+
+	return path, nil
+}
+
+// GetUUID parses values as RFC 4122 UUIDs in upper or lower case with
+// or without braces. The String method of the result returns the
+// canonical lowercase form
+func (g typedGetters) GetUUID(key string) (uuid.UUID, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// uuid.Parse also accepts the 'urn:uuid:' and the unhyphenated form
+	trimmedValue := strings.TrimSpace(stringValue)
+	if len(trimmedValue) != 36 && !(len(trimmedValue) == 38 && trimmedValue[0] == '{') {
+		return uuid.Nil, newTypeConversionErrorWithCause(key, stringValue, "uuid.UUID",
+			errors.New("expected the hyphenated form with or without braces"))
+	}
+
+	value, err := uuid.Parse(trimmedValue)
+	if err != nil {
+		return uuid.Nil, newTypeConversionErrorWithCause(key, stringValue, "uuid.UUID", err)
+	}
+	if value.Variant() != uuid.RFC4122 {
+		return uuid.Nil, newTypeConversionErrorWithCause(key, stringValue, "uuid.UUID",
+			fmt.Errorf("variant '%s' is not RFC 4122", value.Variant()))
+	}
+
+	return value, nil
+}
+
+// GetRegexp compiles values as regular expressions and caches the result
+// until the value changes
+func (g typedGetters) GetRegexp(key string) (*regexp.Regexp, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := g.cached("*regexp.Regexp", key, stringValue, func() (interface{}, error) {
+		compile := regexp.Compile
+		if g.options.posixRegexps {
+			compile = regexp.CompilePOSIX
+		}
+
+		value, err := compile(stringValue)
+		if err != nil {
+			return nil, newTypeConversionErrorWithCause(key, stringValue, "*regexp.Regexp", err)
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*regexp.Regexp), nil
+}
+
+// GetCron parses values as 5-field cron expressions (e.g. '0 3 * * *')
+// or shorthands like '@daily' and caches the result until the value
+// changes. The TypeConversionError of an invalid expression names the
+// invalid field
+func (g typedGetters) GetCron(key string) (*CronSchedule, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := g.cached("*conf.CronSchedule", key, stringValue, func() (interface{}, error) {
+		value, err := parseCron(stringValue)
+		if err != nil {
+			return nil, newTypeConversionErrorWithCause(key, stringValue, "*conf.CronSchedule", err)
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*CronSchedule), nil
+}
+
+// GetSemver parses values as semantic versions (e.g. '1.4.0' or
+// 'v2.0.0-rc.1') that can be compared with each other
+func (g typedGetters) GetSemver(key string) (Semver, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return Semver{}, err
+	}
+
+	value, err := g.cached("semver", key, stringValue, func() (interface{}, error) {
+		value, err := parseSemver(stringValue)
+		if err != nil {
+			return nil, newTypeConversionErrorWithCause(key, stringValue, "semver", err)
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		return Semver{}, err
+	}
+
+	return value.(Semver), nil
+}
+
+// GetSemverConstraint parses values as version constraints (e.g.
+// '>=1.4.0 <2.0.0') that versions can be checked against
+func (g typedGetters) GetSemverConstraint(key string) (*SemverConstraint, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := g.cached("semver constraint", key, stringValue, func() (interface{}, error) {
+		value, err := parseSemverConstraint(stringValue)
+		if err != nil {
+			return nil, newTypeConversionErrorWithCause(key, stringValue, "semver", err)
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*SemverConstraint), nil
+}
+
+var sizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"kb":  1000,
+	"mb":  1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// GetSize parses human-readable sizes with decimal (kB, MB, GB, TB) or
+// binary (KiB, MiB, GiB, TiB) units and returns the number of bytes.
+// Plain numbers are interpreted as bytes
+func (g typedGetters) GetSize(key string) (int64, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok := parseSize(stringValue)
+	if !ok {
+		return 0, NewTypeConversionError(key, stringValue, "size")
+	}
+
+	return value, nil
+}
+
+func parseSize(value string) (int64, bool) {
+	value = strings.TrimSpace(value)
+	end := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end < 0 {
+		end = len(value)
+	}
+
+	number := value[:end]
+	unit, ok := sizeUnits[strings.ToLower(strings.TrimSpace(value[end:]))]
+	if number == "" || !ok {
+		return 0, false
+	}
+
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || n > math.MaxInt64/unit {
+			return 0, false
+		}
+		return n * unit, true
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+	size := math.Round(f * float64(unit))
+	if size >= math.MaxInt64 {
+		return 0, false
+	}
+
+	return int64(size), true
+}
+
+// RateLimit allows Count events Per duration. Burst is 0 unless it is
+// given explicitly
+type RateLimit struct {
+	Count int
+	Per   time.Duration
+	Burst int
+}
+
+var rateLimitUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// GetRateLimit parses values like '100/s', '500 / m' or '100/s burst=20'
+// with the units s, m and h
+func (g typedGetters) GetRateLimit(key string) (RateLimit, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return RateLimit{}, err
+	}
+
+	value, ok := parseRateLimit(stringValue)
+	if !ok {
+		err := errors.New("expected format 'N/unit' with unit s, m or h and an optional 'burst=N'")
+		return RateLimit{}, newTypeConversionErrorWithCause(key, stringValue, "rate limit", err)
+	}
+
+	return value, nil
+}
+
+func parseRateLimit(value string) (RateLimit, bool) {
+	rate, burst, hasBurst := strings.Cut(value, "burst")
+	count, unit, ok := strings.Cut(rate, "/")
+	if !ok {
+		return RateLimit{}, false
+	}
+
+	var result RateLimit
+	var err error
+	if result.Count, err = strconv.Atoi(strings.TrimSpace(count)); err != nil || result.Count < 1 {
+		return RateLimit{}, false
+	}
+	if result.Per, ok = rateLimitUnits[strings.TrimSpace(unit)]; !ok {
+		return RateLimit{}, false
+	}
+
+	if hasBurst {
+		burst, ok := strings.CutPrefix(strings.TrimSpace(burst), "=")
+		if !ok {
+			return RateLimit{}, false
+		}
+		if result.Burst, err = strconv.Atoi(strings.TrimSpace(burst)); err != nil || result.Burst < 1 {
+			return RateLimit{}, false
+		}
+	}
+
+	return result, true
+}
+
+// GetPort parses values as TCP/UDP ports in the range 1-65535 (0 is
+// accepted with WithZeroPort). Values that are not integers result in a
+// TypeConversionError of type 'int', integers outside the port range in
+// one of type 'port'
+func (g typedGetters) GetPort(key string) (int, error) {
+	value, err := g.GetInt(key)
+	if err != nil {
+		return 0, err
+	}
+
+	min := 1
+	if g.options.zeroPort {
+		min = 0
+	}
+	if value < min || value > 65535 {
+		stringValue, _ := g.getString(key)
+		err := fmt.Errorf("port has to be in the range %d-65535", min)
+		return 0, newTypeConversionErrorWithCause(key, stringValue, "port", err)
+	}
+
+	return value, nil
+}
+
+// GetHostPort splits values like 'cache.internal:6379' or '[::1]:6379'
+// into host and port. The port has to be in the same range as for
+// GetPort
+func (g typedGetters) GetHostPort(key string) (string, int, error) {
+	return g.getHostPort(key, 0, false)
+}
+
+// GetHostPortDefault is like GetHostPort but uses defaultPort if the
+// value has no port (e.g. 'cache.internal' or '[::1]')
+func (g typedGetters) GetHostPortDefault(key string, defaultPort int) (string, int, error) {
+	return g.getHostPort(key, defaultPort, true)
+}
+
+func (g typedGetters) getHostPort(key string, defaultPort int, hasDefault bool) (string, int, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return "", 0, err
+	}
+
+	value := strings.TrimSpace(stringValue)
+	host, portValue, err := net.SplitHostPort(value)
+	if err != nil {
+		if !hasDefault || !isHostWithoutPort(value) {
+			return "", 0, newTypeConversionErrorWithCause(key, stringValue, "host:port", err)
+		}
+		host = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	}
+
+	port := defaultPort
+	if portValue != "" || !hasDefault {
+		port, err = strconv.Atoi(portValue)
+		if err != nil {
+			err := fmt.Errorf("port '%s' is not a number", portValue)
+			return "", 0, newTypeConversionErrorWithCause(key, stringValue, "host:port", err)
+		}
+	}
+
+	min := 1
+	if g.options.zeroPort {
+		min = 0
+	}
+	if port < min || port > 65535 {
+		err := fmt.Errorf("port has to be in the range %d-65535", min)
+		return "", 0, newTypeConversionErrorWithCause(key, stringValue, "host:port", err)
+	}
+
+	return host, port, nil
+}
+
+// isHostWithoutPort reports whether value is a plain host name or a
+// bracketed or bare IPv6 literal
+func isHostWithoutPort(value string) bool {
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		return net.ParseIP(value[1:len(value)-1]) != nil
+	}
+	if strings.Contains(value, ":") {
+		return net.ParseIP(value) != nil
+	}
+
+	return value != "" && !strings.ContainsAny(value, "[]/ ")
+}
+
+// GetLogLevel parses the level names debug, info, warn and error case
+// insensitively (optionally with an offset like 'info+2') as well as
+// numeric levels
+func (g typedGetters) GetLogLevel(key string) (slog.Level, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	trimmedValue := strings.TrimSpace(stringValue)
+	if value, err := strconv.Atoi(trimmedValue); err == nil {
+		return slog.Level(value), nil
+	}
+
+	var value slog.Level
+	if err := value.UnmarshalText([]byte(trimmedValue)); err != nil {
+		return 0, NewTypeConversionError(key, stringValue, "slog.Level")
+	}
+
+	return value, nil
+}
+
+// GetEnum returns the value if it is one of the allowed values or an
+// InvalidEnumError otherwise. With WithCaseInsensitiveEnums values match
+// regardless of their case and the allowed spelling is returned
+func (g typedGetters) GetEnum(key string, allowed ...string) (string, error) {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return "", err
+	}
+
+	for _, a := range allowed {
+		if a == stringValue || (g.options.caseInsensitiveEnums && strings.EqualFold(a, stringValue)) {
+			return a, nil
+		}
+	}
+
+	return "", NewInvalidEnumError(key, stringValue, allowed)
+}
+
+// GetStruct unmarshals the JSON value of the given key into out. An
+// empty value is reported as a TypeConversionError instead of leaving
+// out untouched
+func (g typedGetters) GetStruct(key string, out any) error {
+	stringValue, err := g.getString(key)
+	if err != nil {
+		return err
+	}
+
+	typ := reflect.TypeOf(out)
+	if typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	typName := fmt.Sprint(typ)
+
+	if strings.TrimSpace(stringValue) == "" {
+		return newTypeConversionErrorWithCause(key, stringValue, typName, errors.New("value is empty"))
+	}
+
+	if err := json.Unmarshal([]byte(stringValue), out); err != nil {
+		return newTypeConversionErrorWithCause(key, stringValue, typName, err)
+	}
+
+	return nil
+}
+
+// isKeyNotFound only matches a KeyNotFoundError itself and not errors
+// wrapping one so failures are never mistaken for missing keys
+func isKeyNotFound(err error) bool {
+	_, ok := err.(*KeyNotFoundError)
+	return ok
+}
@@ -0,0 +1,94 @@
+package conf
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const defaultInterpolationDepth = 10
+
+// InterpolationError indicates that a '${key}' reference in the value
+// of Key could not be expanded
+type InterpolationError struct {
+	Key     string
+	message string
+}
+
+func NewInterpolationError(key, reason string) *InterpolationError {
+	return &InterpolationError{
+		Key:     key,
+		message: fmt.Sprintf("value of key '%s' could not be interpolated: %s", key, reason),
+	}
+}
+
+func (e *InterpolationError) Error() string {
+	return e.message
+}
+
+// interpolate expands '${key}' references in value with the values of
+// other keys of the store (or environment variables with
+// WithEnvInterpolation). '$$' is an escaped '$'
+func interpolate(store map[string]string, key, value string, o options) (string, error) {
+	return expand(store, key, value, o, []string{key})
+}
+
+func expand(store map[string]string, key, value string, o options, path []string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+
+		switch value[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				return "", NewInterpolationError(path[0], fmt.Sprintf("unterminated reference in value of key '%s'", key))
+			}
+			reference := value[i+2 : i+2+end]
+
+			resolved, err := resolveReference(store, reference, o, path)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(resolved)
+			i += 2 + end
+		default:
+			b.WriteByte('$')
+		}
+	}
+
+	return b.String(), nil
+}
+
+func resolveReference(store map[string]string, reference string, o options, path []string) (string, error) {
+	for i, key := range path {
+		if key == reference {
+			cycle := strings.Join(append(path[i:], reference), " -> ")
+			return "", NewInterpolationError(path[0], "reference cycle "+cycle)
+		}
+	}
+	if len(path) > o.interpolationDepth {
+		return "", NewInterpolationError(path[0], fmt.Sprintf("references are nested deeper than %d levels", o.interpolationDepth))
+	}
+
+	if value, ok := store[o.normalizeKey(reference)]; ok {
+		return expand(store, reference, value, o, append(path[:len(path):len(path)], reference))
+	}
+	if o.envInterpolation {
+		if value, ok := os.LookupEnv(reference); ok {
+			return value, nil
+		}
+	}
+
+	return "", NewInterpolationError(path[0], fmt.Sprintf("referenced key '%s' not found", reference))
+}
@@ -0,0 +1,86 @@
+package conf
+
+import (
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+const interpolationFile = "testdata/interpolation.properties"
+
+func TestInterpolation(t *testing.T) {
+	cp := NewFileConfigProvider(interpolationFile, WithInterpolation())
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"base_url", "http://localhost:8080"},
+		{"api_url", "http://localhost:8080/api"},
+		{"price", "$5 for localhost"},
+		{"dollar", "a $ b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := cp.GetString(test.key)
+			AssertEquals(t, nil, err, "cp.GetString error")
+			AssertEquals(t, test.expected, value, "cp.GetString value")
+		})
+	}
+}
+
+func TestInterpolationErrors(t *testing.T) {
+	cp := NewFileConfigProvider(interpolationFile, WithInterpolation())
+
+	tests := []struct {
+		key      string
+		expected error
+	}{
+		{"missing", NewInterpolationError("missing", "referenced key 'nope' not found")},
+		{"self", NewInterpolationError("self", "reference cycle self -> self")},
+		{"cycle.a", NewInterpolationError("cycle.a", "reference cycle cycle.a -> cycle.b -> cycle.a")},
+		{"unterminated", NewInterpolationError("unterminated", "unterminated reference in value of key 'unterminated'")},
+		{"env", NewInterpolationError("env", "referenced key 'SOLVENT_TEST_INTERPOLATION' not found")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			_, err := cp.GetString(test.key)
+			AssertEquals(t, test.expected, err, "cp.GetString error")
+		})
+	}
+}
+
+func TestInterpolationDepth(t *testing.T) {
+	cp := NewFileConfigProvider(interpolationFile, WithInterpolation(), WithInterpolationDepth(1))
+
+	_, err := cp.GetString("api_url")
+	AssertEquals(t, NewInterpolationError("api_url", "references are nested deeper than 1 levels"), err, "cp.GetString error")
+
+	value, err := cp.GetString("base_url")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "http://localhost:8080", value, "cp.GetString value")
+}
+
+func TestEnvInterpolation(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_INTERPOLATION", "from-env")
+	t.Setenv("host", "env-host")
+	cp := NewFileConfigProvider(interpolationFile, WithEnvInterpolation())
+
+	value, err := cp.GetString("env")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "from-env", value, "cp.GetString value")
+
+	value, err = cp.GetString("base_url")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "http://localhost:8080", value, "cp.GetString value")
+}
+
+func TestWithoutInterpolation(t *testing.T) {
+	cp := NewFileConfigProvider(interpolationFile)
+
+	value, err := cp.GetString("base_url")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "${scheme}://${host}:${port}", value, "cp.GetString value")
+}
@@ -0,0 +1,130 @@
+package conf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// JSONFileConfigProvider reads config values from a JSON object where
+// nested objects are flattened into dot-delimited keys (e.g.
+// {"server":{"port":8080}} is available as 'server.port')
+type JSONFileConfigProvider struct {
+	typedGetters
+	doc *document
+}
+
+// NewJSONFileConfigProvider creates a new JSONFileConfigProvider for the
+// JSON file at the given path and returns the error of reading or
+// parsing it. The file is read again on the next access after its
+// modification time changed. Paths are resolved like in
+// NewFileConfigProvider
+func NewJSONFileConfigProvider(path string, opts ...Option) (*JSONFileConfigProvider, error) {
+	cp := &JSONFileConfigProvider{}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+	cp.doc = newFileDocument(callerRelativePath(path), cp.options.reloadCheckInterval, cp.parse)
+	if _, err := cp.doc.loadStore(); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// NewJSONConfigProviderFromReader creates a new JSONFileConfigProvider
+// that lazily reads its JSON object from the given reader
+func NewJSONConfigProviderFromReader(r io.Reader, opts ...Option) *JSONFileConfigProvider {
+	cp := &JSONFileConfigProvider{}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+	cp.doc = newReaderDocument(r, cp.parse)
+
+	return cp
+}
+
+func (cp *JSONFileConfigProvider) GetString(key string) (string, error) {
+	return cp.doc.getString(key, cp.options)
+}
+
+// Keys returns the sorted flattened keys or nil if the JSON object cannot
+// be read
+func (cp *JSONFileConfigProvider) Keys() []string {
+	return cp.doc.keys()
+}
+
+// All returns a copy of all flattened values
+func (cp *JSONFileConfigProvider) All() (map[string]string, error) {
+	return cp.doc.all()
+}
+
+func (cp *JSONFileConfigProvider) parse(r io.Reader) (map[string]string, error) {
+	store, err := initMapFromJSON(r, cp.options.separator)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeKeys(store, cp.options)
+}
+
+// initMapFromJSON flattens the JSON object read from r and joins arrays
+// of scalars with the given separator so GetStringSlice can split them
+func initMapFromJSON(r io.Reader, separator string) (map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &UnknownError{
+			err:     err,
+			message: "could not read JSON config",
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, jsonParsingError(data, err)
+	}
+
+	// Anything but whitespace after the object is rejected instead of
+	// being silently ignored
+	end := decoder.InputOffset()
+	if err := decoder.Decode(&json.RawMessage{}); err != io.EOF {
+		if err != nil {
+			return nil, jsonParsingError(data, err)
+		}
+		rest := data[end:]
+		end += int64(len(rest) - len(bytes.TrimLeft(rest, " \t\r\n")))
+		return nil, jsonParsingErrorAt(data, end, errors.New("unexpected content after the top-level object"))
+	}
+
+	store := map[string]string{}
+	flattenValue(store, "", object, separator)
+
+	return store, nil
+}
+
+// jsonParsingError returns a ParsingError for the line of the JSON
+// document the given decoding error points to. Errors without an offset
+// are reported with their message
+func jsonParsingError(data []byte, err error) *ParsingError {
+	var offset int64 = -1
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	} else if errors.As(err, &typeErr) {
+		offset = typeErr.Offset
+	}
+
+	return jsonParsingErrorAt(data, offset, err)
+}
+
+// jsonParsingErrorAt returns a ParsingError for the line of the given
+// offset or one with the message of err if the offset is unknown
+func jsonParsingErrorAt(data []byte, offset int64, err error) *ParsingError {
+	if offset < 0 || offset > int64(len(data)) {
+		return NewParsingError(err.Error())
+	}
+
+	lineNumber := bytes.Count(data[:offset], []byte("\n")) + 1
+	return parsingErrorAt(string(data), lineNumber, err)
+}
@@ -0,0 +1,235 @@
+package conf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func newJSONFileProvider(t *testing.T, path string, opts ...Option) *JSONFileConfigProvider {
+	t.Helper()
+
+	cp, err := NewJSONFileConfigProvider(path, opts...)
+	AssertEquals(t, nil, err, "NewJSONFileConfigProvider error")
+
+	return cp
+}
+
+func TestJSONGetString(t *testing.T) {
+	cp := newJSONFileProvider(t, "testdata/test.json")
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"server.host", "localhost"},
+		{"server.port", "8080"},
+		{"server.tls", "false"},
+		{"origins", "https://a.com,https://b.com"},
+		{"ratio", "0.75"},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetString(test.key)
+		AssertEquals(t, nil, err, "cp.GetString error")
+		AssertEquals(t, test.expected, value, "cp.GetString value")
+	}
+
+	_, err := cp.GetString("optional")
+	AssertEquals(t, NewKeyNotFoundError("optional"), err, "cp.GetString error")
+
+	_, err = cp.GetString("server")
+	AssertEquals(t, NewKeyNotFoundError("server"), err, "cp.GetString error")
+}
+
+func TestJSONTypedGetters(t *testing.T) {
+	cp := NewJSONConfigProviderFromReader(strings.NewReader(`{"port": 8080, "tls": true}`))
+
+	port, err := cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 8080, port, "cp.GetInt value")
+
+	tls, err := cp.GetBool("tls")
+	AssertEquals(t, nil, err, "cp.GetBool error")
+	AssertEquals(t, true, tls, "cp.GetBool value")
+
+	_, err = cp.GetBool("port")
+	AssertEquals(t, NewTypeConversionError("port", "8080", "bool"), err, "cp.GetBool error")
+
+	_, err = cp.GetFloat("tls")
+	AssertEquals(t, NewTypeConversionError("tls", "true", "float64"), err, "cp.GetFloat error")
+}
+
+func TestJSONMalformed(t *testing.T) {
+	tests := []struct {
+		data       string
+		lineNumber int
+		line       string
+		cause      string
+	}{
+		{"{\n  \"port\": 8080,\n  \"host\" localhost\n}", 3, `"host" localhost`, "invalid character 'l' after object key"},
+		{`["port"]`, 1, `["port"]`, "json: cannot unmarshal array into Go value of type map[string]interface {}"},
+		{`{"a":1}}garbage`, 1, `{"a":1}}garbage`, "invalid character '}' looking for beginning of value"},
+		{"{\"a\": 1}\n{\"b\": 2}\n", 2, `{"b": 2}`, "unexpected content after the top-level object"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.data, func(t *testing.T) {
+			cp := NewJSONConfigProviderFromReader(strings.NewReader(test.data))
+
+			_, err := cp.GetString("port")
+			var parsingErr *ParsingError
+			AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+			AssertEquals(t, test.lineNumber, parsingErr.LineNumber, "parsingErr.LineNumber")
+			AssertEquals(t, test.line, parsingErr.Line, "parsingErr.Line")
+			AssertEquals(t, test.cause, errors.Unwrap(err).Error(), "parsingErr cause")
+			AssertEquals(t, NewParsingErrorAt(test.lineNumber, test.line).Error()+": "+test.cause, err.Error(), "cp.GetString error")
+		})
+	}
+
+	cp := NewJSONConfigProviderFromReader(strings.NewReader("{\"a\": 1}\n\n"))
+
+	value, err := cp.GetString("a")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "1", value, "cp.GetString value")
+}
+
+func TestJSONMixedArray(t *testing.T) {
+	cp := NewJSONConfigProviderFromReader(strings.NewReader(`{"items": [1, {"a": 2}, "three", null]}`))
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"items.0", "1"},
+		{"items.1.a", "2"},
+		{"items.2", "three"},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetString(test.key)
+		AssertEquals(t, nil, err, "cp.GetString error")
+		AssertEquals(t, test.expected, value, "cp.GetString value")
+	}
+
+	AssertEquals(t, []string{"items.0", "items.1.a", "items.2"}, cp.Keys(), "cp.Keys")
+}
+
+func TestJSONGetInt64(t *testing.T) {
+	cp := NewJSONConfigProviderFromReader(strings.NewReader(`{"id": 9007199254740993}`))
+
+	value, err := cp.GetInt64("id")
+	AssertEquals(t, nil, err, "cp.GetInt64 error")
+	AssertEquals(t, int64(9007199254740993), value, "cp.GetInt64 value")
+}
+
+func TestJSONGetStringSlice(t *testing.T) {
+	cp := newJSONFileProvider(t, "testdata/test.json")
+
+	value, err := cp.GetStringSlice("origins")
+	AssertEquals(t, nil, err, "cp.GetStringSlice error")
+	AssertEquals(t, []string{"https://a.com", "https://b.com"}, value, "cp.GetStringSlice value")
+
+	cp = NewJSONConfigProviderFromReader(strings.NewReader(`{"names": ["Doe, John", "Roe, Jane"], "empty": []}`), WithSeparator(";"))
+
+	value, err = cp.GetStringSlice("names")
+	AssertEquals(t, nil, err, "cp.GetStringSlice error")
+	AssertEquals(t, []string{"Doe, John", "Roe, Jane"}, value, "cp.GetStringSlice value")
+
+	value, err = cp.GetStringSlice("empty")
+	AssertEquals(t, nil, err, "cp.GetStringSlice error")
+	AssertEquals(t, []string{}, value, "cp.GetStringSlice value")
+}
+
+func TestJSONConcurrentGetString(t *testing.T) {
+	cp := newJSONFileProvider(t, "testdata/test.json")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cp.GetString("server.host")
+			AssertEquals(t, nil, err, "cp.GetString error")
+			AssertEquals(t, "localhost", value, "cp.GetString value")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestJSONKeys(t *testing.T) {
+	cp := newJSONFileProvider(t, "testdata/test.json")
+
+	expected := []string{"origins", "ratio", "server.host", "server.port", "server.tls"}
+	AssertEquals(t, expected, cp.Keys(), "cp.Keys")
+}
+
+func TestJSONReloadOnModification(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"database": {"host": "db", "port": 5432}}`)
+	cp := newJSONFileProvider(t, path, WithReloadCheckInterval(0))
+
+	value, err := cp.GetString("database.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "db", value, "cp.GetString value")
+
+	writeFile(t, path, `{"database": {"host": "replica", "port": 5433}}`)
+	modTime := time.Now().Add(time.Minute)
+	AssertEquals(t, nil, os.Chtimes(path, modTime, modTime), "os.Chtimes error")
+
+	value, err = cp.GetString("database.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "replica", value, "cp.GetString value")
+
+	port, err := cp.GetInt("database.port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 5433, port, "cp.GetInt value")
+
+	writeFile(t, path, `{"database": `)
+	modTime = modTime.Add(time.Minute)
+	AssertEquals(t, nil, os.Chtimes(path, modTime, modTime), "os.Chtimes error")
+
+	_, err = cp.GetString("database.host")
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+}
+
+func TestJSONFileErrors(t *testing.T) {
+	cp, err := NewJSONFileConfigProvider("testdata/missing.json")
+	var unknownErr *UnknownError
+	AssertEquals(t, true, errors.As(err, &unknownErr), "errors.As UnknownError")
+	AssertEquals(t, (*JSONFileConfigProvider)(nil), cp, "cp")
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, "{\n  \"port\": \n}")
+	_, err = NewJSONFileConfigProvider(path)
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+	AssertEquals(t, 3, parsingErr.LineNumber, "parsingErr.LineNumber")
+}
+
+func TestJSONReloadCheckInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"host": "db"}`)
+	cp := newJSONFileProvider(t, path, WithReloadCheckInterval(time.Hour))
+
+	writeFile(t, path, `{"host": "replica"}`)
+	modTime := time.Now().Add(time.Minute)
+	AssertEquals(t, nil, os.Chtimes(path, modTime, modTime), "os.Chtimes error")
+
+	// The modification is not noticed before the interval passed
+	value, err := cp.GetString("host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "db", value, "cp.GetString value")
+
+	cp.doc.checked = time.Now().Add(-time.Hour)
+	value, err = cp.GetString("host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "replica", value, "cp.GetString value")
+}
@@ -0,0 +1,137 @@
+package conf
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LookupString returns the value of key and whether it exists and could
+// be read. For a FileConfigProvider no error is allocated if the key is
+// missing which makes it suitable for lookups on hot paths
+func (g typedGetters) LookupString(key string) (string, bool) {
+	if g.lookupString != nil {
+		return g.lookupString(key)
+	}
+
+	value, err := g.getString(key)
+	return value, err == nil
+}
+
+// LookupBool is like LookupString but also reports false if the value
+// cannot be converted to a bool
+func (g typedGetters) LookupBool(key string) (bool, bool) {
+	stringValue, ok := g.LookupString(key)
+	if !ok {
+		return false, false
+	}
+
+	if g.options.lenientBools {
+		stringValue = strings.ToLower(strings.TrimSpace(stringValue))
+		if value, ok := lenientBools[stringValue]; ok {
+			return value, true
+		}
+	}
+
+	value, err := strconv.ParseBool(stringValue)
+	return value, err == nil
+}
+
+// LookupFloat is like LookupString but also reports false if the value
+// cannot be converted to a float64
+func (g typedGetters) LookupFloat(key string) (float64, bool) {
+	stringValue, ok := g.LookupString(key)
+	if !ok {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(stringValue, 64)
+	return value, err == nil
+}
+
+func (cp *FileConfigProvider) lookupString(key string) (string, bool) {
+	store, err := cp.loadStore()
+	if err != nil {
+		return "", false
+	}
+
+	value, ok := store[cp.options.normalizeKey(key)]
+	if !ok || !cp.options.interpolation {
+		return value, ok
+	}
+
+	value, err = interpolate(store, key, value, cp.options)
+	return value, err == nil
+}
+
+// LookupString returns the value of the first provider that has the key
+func (cp *ChainConfigProvider) LookupString(key string) (string, bool) {
+	chain, _ := cp.providers()
+	for i := range chain {
+		if value, ok := chain[i].LookupString(key); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// LookupBool returns the value of the first provider that has the key
+// with a value convertible to a bool
+func (cp *ChainConfigProvider) LookupBool(key string) (bool, bool) {
+	chain, _ := cp.providers()
+	for i := range chain {
+		if value, ok := chain[i].LookupBool(key); ok {
+			return value, true
+		}
+	}
+
+	return false, false
+}
+
+// LookupFloat returns the value of the first provider that has the key
+// with a value convertible to a float64
+func (cp *ChainConfigProvider) LookupFloat(key string) (float64, bool) {
+	chain, _ := cp.providers()
+	for i := range chain {
+		if value, ok := chain[i].LookupFloat(key); ok {
+			return value, true
+		}
+	}
+
+	return 0, false
+}
+
+// TryGetString is like GetString but reports whether it succeeded
+// instead of returning an error
+func (cp *ChainConfigProvider) TryGetString(key string) (string, bool) {
+	value, err := cp.GetString(key)
+	if err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// TryGetFloat is like GetFloat but reports whether it succeeded instead
+// of returning an error. Unlike LookupFloat a value that cannot be
+// converted is not skipped in favour of later providers
+func (cp *ChainConfigProvider) TryGetFloat(key string) (float64, bool) {
+	value, err := cp.GetFloat(key)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// TryGetBool is like GetBool but reports whether it succeeded instead of
+// returning an error. Unlike LookupBool a value that cannot be converted
+// is not skipped in favour of later providers
+func (cp *ChainConfigProvider) TryGetBool(key string) (bool, bool) {
+	value, err := cp.GetBool(key)
+	if err != nil {
+		return false, false
+	}
+
+	return value, true
+}
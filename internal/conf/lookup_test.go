@@ -0,0 +1,97 @@
+package conf
+
+import (
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestLookup(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	value, ok := cp.LookupString("string")
+	AssertEquals(t, true, ok, "cp.LookupString ok")
+	AssertEquals(t, "value", value, "cp.LookupString value")
+
+	_, ok = cp.LookupString("missing")
+	AssertEquals(t, false, ok, "cp.LookupString missing")
+
+	b, ok := cp.LookupBool("bool")
+	AssertEquals(t, true, ok, "cp.LookupBool ok")
+	AssertEquals(t, true, b, "cp.LookupBool value")
+
+	f, ok := cp.LookupFloat("float")
+	AssertEquals(t, true, ok, "cp.LookupFloat ok")
+	AssertEquals(t, 3.14, f, "cp.LookupFloat value")
+
+	// Present but unconvertible values are reported as not ok
+	_, ok = cp.LookupBool("string")
+	AssertEquals(t, false, ok, "cp.LookupBool unconvertible")
+	_, ok = cp.LookupFloat("string")
+	AssertEquals(t, false, ok, "cp.LookupFloat unconvertible")
+
+	_, ok = NewFileConfigProvider("testdata/missing.properties").LookupString("string")
+	AssertEquals(t, false, ok, "LookupString unreadable file")
+}
+
+func TestChainLookup(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{"bool": "maybe", "only.memory": "1.5"}),
+		NewFileConfigProvider(testFile),
+	})
+
+	b, ok := cp.LookupBool("bool")
+	AssertEquals(t, true, ok, "cp.LookupBool ok")
+	AssertEquals(t, true, b, "cp.LookupBool falls through unconvertible value")
+
+	f, ok := cp.LookupFloat("only.memory")
+	AssertEquals(t, true, ok, "cp.LookupFloat ok")
+	AssertEquals(t, 1.5, f, "cp.LookupFloat value")
+
+	_, ok = cp.LookupString("missing")
+	AssertEquals(t, false, ok, "cp.LookupString missing")
+}
+
+func TestChainTryGet(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{"bool": "maybe", "only.memory": "1.5"}),
+		NewFileConfigProvider(testFile),
+	})
+
+	s, ok := cp.TryGetString("string")
+	AssertEquals(t, true, ok, "cp.TryGetString ok")
+	AssertEquals(t, "value", s, "cp.TryGetString value")
+
+	f, ok := cp.TryGetFloat("only.memory")
+	AssertEquals(t, true, ok, "cp.TryGetFloat ok")
+	AssertEquals(t, 1.5, f, "cp.TryGetFloat value")
+
+	b, ok := cp.TryGetBool("bool")
+	AssertEquals(t, false, ok, "cp.TryGetBool does not fall through unconvertible value")
+	AssertEquals(t, false, b, "cp.TryGetBool zero value")
+
+	s, ok = cp.TryGetString("missing")
+	AssertEquals(t, false, ok, "cp.TryGetString missing")
+	AssertEquals(t, "", s, "cp.TryGetString zero value")
+	f, ok = cp.TryGetFloat("missing")
+	AssertEquals(t, false, ok, "cp.TryGetFloat missing")
+	AssertEquals(t, 0.0, f, "cp.TryGetFloat zero value")
+	_, ok = cp.TryGetBool("missing")
+	AssertEquals(t, false, ok, "cp.TryGetBool missing")
+}
+
+func BenchmarkGetStringMissing(b *testing.B) {
+	cp := NewFileConfigProvider(testFile)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = cp.GetString("missing")
+	}
+}
+
+func BenchmarkLookupStringMissing(b *testing.B) {
+	cp := NewFileConfigProvider(testFile)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = cp.LookupString("missing")
+	}
+}
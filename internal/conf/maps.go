@@ -0,0 +1,97 @@
+package conf
+
+import (
+	"sort"
+	"strings"
+)
+
+// GetStringMap returns the values of all keys below the given prefix
+// (e.g. 'limits.tenant-a' for the prefix 'limits') with the prefix
+// removed. For a ChainConfigProvider the maps of all providers are
+// merged with earlier providers taking precedence. If no key matches a
+// KeyNotFoundError for the prefix is returned, errors loading the config
+// are returned as is
+func GetStringMap(cp ConfigProvider, prefix string) (map[string]string, error) {
+	return getMap(cp, prefix, cp.GetString)
+}
+
+// GetIntMap is like GetStringMap but converts every value like GetInt.
+// The returned TypeConversionError names the full key that failed
+func GetIntMap(cp ConfigProvider, prefix string) (map[string]int, error) {
+	return getMap(cp, prefix, cp.GetInt)
+}
+
+// GetFloatMap is like GetStringMap but converts every value like
+// GetFloat
+func GetFloatMap(cp ConfigProvider, prefix string) (map[string]float64, error) {
+	return getMap(cp, prefix, cp.GetFloat)
+}
+
+// GetStringMap is like the package function GetStringMap for the keys
+// of the file
+func (cp *FileConfigProvider) GetStringMap(prefix string) (map[string]string, error) {
+	return GetStringMap(cp, prefix)
+}
+
+// GetIntMap is like the package function GetIntMap for the keys of the
+// file
+func (cp *FileConfigProvider) GetIntMap(prefix string) (map[string]int, error) {
+	return GetIntMap(cp, prefix)
+}
+
+// GetFloatMap is like the package function GetFloatMap for the keys of
+// the file
+func (cp *FileConfigProvider) GetFloatMap(prefix string) (map[string]float64, error) {
+	return GetFloatMap(cp, prefix)
+}
+
+// GetStringMap merges the maps of all providers with earlier providers
+// taking precedence on key collisions
+func (cp *ChainConfigProvider) GetStringMap(prefix string) (map[string]string, error) {
+	return GetStringMap(cp, prefix)
+}
+
+// GetIntMap is like GetStringMap but converts every value like GetInt
+func (cp *ChainConfigProvider) GetIntMap(prefix string) (map[string]int, error) {
+	return GetIntMap(cp, prefix)
+}
+
+// GetFloatMap is like GetStringMap but converts every value like
+// GetFloat
+func (cp *ChainConfigProvider) GetFloatMap(prefix string) (map[string]float64, error) {
+	return GetFloatMap(cp, prefix)
+}
+
+func getMap[T any](cp ConfigProvider, prefix string, get func(key string) (T, error)) (map[string]T, error) {
+	all, err := cp.All()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sorted so the same key fails first when several values are invalid
+	keys := make([]string, 0, len(all))
+	for key := range all {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	keyPrefix := prefix + "."
+	values := map[string]T{}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, keyPrefix) || key == keyPrefix {
+			continue
+		}
+
+		value, err := get(key)
+		if err != nil {
+			return nil, err
+		}
+		values[strings.TrimPrefix(key, keyPrefix)] = value
+	}
+
+	if len(values) == 0 {
+		return nil, NewKeyNotFoundError(prefix)
+	}
+
+	return values, nil
+}
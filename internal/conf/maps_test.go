@@ -0,0 +1,85 @@
+package conf
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestGetStringMap(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"limits.tenant-a":   "100",
+		"limits.tenant-b":   "250",
+		"limits.nested.c":   "5",
+		"limitsx.tenant-d":  "1",
+		"other.limits.e":    "2",
+		"ratios.tenant-a":   "0.5",
+		"ratios.tenant-bad": "half",
+	})
+
+	values, err := GetStringMap(cp, "limits")
+	AssertEquals(t, nil, err, "GetStringMap error")
+	AssertEquals(t, map[string]string{"tenant-a": "100", "tenant-b": "250", "nested.c": "5"}, values, "GetStringMap value")
+
+	ints, err := GetIntMap(cp, "limits")
+	AssertEquals(t, nil, err, "GetIntMap error")
+	AssertEquals(t, map[string]int{"tenant-a": 100, "tenant-b": 250, "nested.c": 5}, ints, "GetIntMap value")
+
+	_, err = GetFloatMap(cp, "ratios")
+	AssertEquals(t, NewTypeConversionError("ratios.tenant-bad", "half", "float64"), err, "GetFloatMap error")
+
+	_, err = GetStringMap(cp, "missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "GetStringMap error")
+}
+
+func TestChainGetStringMap(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{"limits.tenant-a": "150"}),
+		NewInMemoryConfigProvider(map[string]string{"limits.tenant-a": "100", "limits.tenant-b": "250"}),
+	})
+
+	values, err := GetIntMap(cp, "limits")
+	AssertEquals(t, nil, err, "GetIntMap error")
+	AssertEquals(t, map[string]int{"tenant-a": 150, "tenant-b": 250}, values, "GetIntMap value")
+}
+
+func TestFileAndChainMapMethods(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.properties")
+	writeFile(t, path, "limits.tenant-a=100\nlimits.tenant-b=250\nratios.tenant-a=0.5\n")
+	file := NewFileConfigProvider(path)
+
+	values, err := file.GetStringMap("limits")
+	AssertEquals(t, nil, err, "file.GetStringMap error")
+	AssertEquals(t, map[string]string{"tenant-a": "100", "tenant-b": "250"}, values, "file.GetStringMap value")
+
+	ratios, err := file.GetFloatMap("ratios")
+	AssertEquals(t, nil, err, "file.GetFloatMap error")
+	AssertEquals(t, map[string]float64{"tenant-a": 0.5}, ratios, "file.GetFloatMap value")
+
+	_, err = file.GetIntMap("missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "file.GetIntMap error")
+
+	chain := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{"limits.tenant-a": "150", "limits.tenant-c": "5"}),
+		file,
+	})
+
+	ints, err := chain.GetIntMap("limits")
+	AssertEquals(t, nil, err, "chain.GetIntMap error")
+	AssertEquals(t, map[string]int{"tenant-a": 150, "tenant-b": 250, "tenant-c": 5}, ints, "chain.GetIntMap value")
+}
+
+func TestGetStringMapUnreadableFile(t *testing.T) {
+	cp := NewFileConfigProvider("/nonexistent/x.properties")
+	_, expectedErr := cp.All()
+	var unknownErr *UnknownError
+	AssertEquals(t, true, errors.As(expectedErr, &unknownErr), "errors.As UnknownError")
+
+	_, err := GetStringMap(cp, "limits")
+	AssertEquals(t, expectedErr, err, "GetStringMap error")
+
+	_, err = GetIntMap(NewChainConfigProvider([]ConfigProvider{cp}), "limits")
+	AssertEquals(t, true, errors.As(err, &unknownErr), "chained GetIntMap error")
+}
@@ -0,0 +1,464 @@
+package conf
+
+import (
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryConfigProvider holds config values in memory. It is meant for
+// tests and as a writable override layer in a ChainConfigProvider
+type InMemoryConfigProvider struct {
+	typedGetters
+	store map[string]string
+	mutex sync.RWMutex
+}
+
+// NewInMemoryConfigProvider creates a new InMemoryConfigProvider
+// initialized with a copy of the given values
+func NewInMemoryConfigProvider(data map[string]string, opts ...Option) *InMemoryConfigProvider {
+	cp := &InMemoryConfigProvider{
+		store: copyMap(data),
+	}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+
+	return cp
+}
+
+func (cp *InMemoryConfigProvider) GetString(key string) (string, error) {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+
+	value, ok := cp.store[key]
+	if !ok {
+		return "", NewKeyNotFoundError(key)
+	}
+
+	return value, nil
+}
+
+// Keys returns the sorted keys of all stored values
+func (cp *InMemoryConfigProvider) Keys() []string {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+
+	return sortedKeys(cp.store)
+}
+
+// All returns a copy of all stored values
+func (cp *InMemoryConfigProvider) All() (map[string]string, error) {
+	cp.mutex.RLock()
+	defer cp.mutex.RUnlock()
+
+	return copyMap(cp.store), nil
+}
+
+// Set stores the value for the given key, replacing any previous value
+func (cp *InMemoryConfigProvider) Set(key, value string) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	cp.store[key] = value
+}
+
+// Delete removes the given key so subsequent lookups report it missing
+func (cp *InMemoryConfigProvider) Delete(key string) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	delete(cp.store, key)
+}
+
+// DefaultingConfigProvider consults an inner provider first and falls
+// back to a set of default values only if the key is missing there
+type DefaultingConfigProvider struct {
+	inner    ConfigProvider
+	defaults *InMemoryConfigProvider
+}
+
+// NewDefaultingConfigProvider wraps inner with the given defaults.
+// Errors other than a KeyNotFoundError (e.g. a TypeConversionError) are
+// returned unchanged instead of falling back
+func NewDefaultingConfigProvider(inner ConfigProvider, defaults map[string]string, opts ...Option) *DefaultingConfigProvider {
+	return &DefaultingConfigProvider{
+		inner:    inner,
+		defaults: NewInMemoryConfigProvider(defaults, opts...),
+	}
+}
+
+// WithDefault sets the default value of the given key and returns the
+// provider to allow chaining
+func (cp *DefaultingConfigProvider) WithDefault(key, value string) *DefaultingConfigProvider {
+	cp.defaults.Set(key, value)
+
+	return cp
+}
+
+func (cp *DefaultingConfigProvider) providerOptions() (options, bool) {
+	if p, ok := cp.inner.(optionsProvider); ok {
+		if o, ok := p.providerOptions(); ok {
+			return o, true
+		}
+	}
+
+	return cp.defaults.providerOptions()
+}
+
+// Keys returns the sorted keys of inner and the defaults or nil if
+// inner cannot be read
+func (cp *DefaultingConfigProvider) Keys() []string {
+	all, err := cp.All()
+	if err != nil {
+		return nil
+	}
+
+	return sortedKeys(all)
+}
+
+// All returns the defaults overridden by the values of inner
+func (cp *DefaultingConfigProvider) All() (map[string]string, error) {
+	values, err := cp.inner.All()
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := cp.defaults.All()
+	for key, value := range values {
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+func (cp *DefaultingConfigProvider) Has(key string) bool {
+	has, _ := cp.HasErr(key)
+	return has
+}
+
+func (cp *DefaultingConfigProvider) HasErr(key string) (bool, error) {
+	has, err := cp.inner.HasErr(key)
+	if err != nil || has {
+		return has, err
+	}
+
+	return cp.defaults.HasErr(key)
+}
+
+func (cp *DefaultingConfigProvider) LookupString(key string) (string, bool) {
+	value, err := cp.GetString(key)
+	return value, err == nil
+}
+
+func (cp *DefaultingConfigProvider) LookupBool(key string) (bool, bool) {
+	value, err := cp.GetBool(key)
+	return value, err == nil
+}
+
+func (cp *DefaultingConfigProvider) LookupFloat(key string) (float64, bool) {
+	value, err := cp.GetFloat(key)
+	return value, err == nil
+}
+
+func (cp *DefaultingConfigProvider) GetString(key string) (string, error) {
+	value, err := cp.inner.GetString(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetString(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetFloat(key string) (float64, error) {
+	value, err := cp.inner.GetFloat(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetFloat(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetBool(key string) (bool, error) {
+	value, err := cp.inner.GetBool(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetBool(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetInt(key string) (int, error) {
+	value, err := cp.inner.GetInt(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetInt(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetInt64(key string) (int64, error) {
+	value, err := cp.inner.GetInt64(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetInt64(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetUint(key string) (uint, error) {
+	value, err := cp.inner.GetUint(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetUint(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetDuration(key string) (time.Duration, error) {
+	value, err := cp.inner.GetDuration(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetDuration(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetTime(key string) (time.Time, error) {
+	value, err := cp.inner.GetTime(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetTime(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetStringSlice(key string) ([]string, error) {
+	value, err := cp.inner.GetStringSlice(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetStringSlice(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetIntSlice(key string) ([]int, error) {
+	value, err := cp.inner.GetIntSlice(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetIntSlice(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetFloatSlice(key string) ([]float64, error) {
+	value, err := cp.inner.GetFloatSlice(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetFloatSlice(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetURL(key string, schemes ...string) (*url.URL, error) {
+	value, err := cp.inner.GetURL(key, schemes...)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetURL(key, schemes...)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetBytes(key string, encoding ...Encoding) ([]byte, error) {
+	value, err := cp.inner.GetBytes(key, encoding...)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetBytes(key, encoding...)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetIP(key string) (net.IP, error) {
+	value, err := cp.inner.GetIP(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetIP(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetCIDR(key string) (*net.IPNet, error) {
+	value, err := cp.inner.GetCIDR(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetCIDR(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetRegexp(key string) (*regexp.Regexp, error) {
+	value, err := cp.inner.GetRegexp(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetRegexp(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetSize(key string) (int64, error) {
+	value, err := cp.inner.GetSize(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetSize(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetRateLimit(key string) (RateLimit, error) {
+	value, err := extended(cp.inner).GetRateLimit(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetRateLimit(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetPort(key string) (int, error) {
+	value, err := cp.inner.GetPort(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetPort(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetLogLevel(key string) (slog.Level, error) {
+	value, err := cp.inner.GetLogLevel(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetLogLevel(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetEnum(key string, allowed ...string) (string, error) {
+	value, err := cp.inner.GetEnum(key, allowed...)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetEnum(key, allowed...)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetStruct(key string, out any) error {
+	err := cp.inner.GetStruct(key, out)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetStruct(key, out)
+	}
+
+	return err
+}
+
+func (cp *DefaultingConfigProvider) GetLocation(key string) (*time.Location, error) {
+	value, err := cp.inner.GetLocation(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetLocation(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetUUID(key string) (uuid.UUID, error) {
+	value, err := extended(cp.inner).GetUUID(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetUUID(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetHostPort(key string) (string, int, error) {
+	host, port, err := cp.inner.GetHostPort(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetHostPort(key)
+	}
+
+	return host, port, err
+}
+
+func (cp *DefaultingConfigProvider) GetHostPortDefault(key string, defaultPort int) (string, int, error) {
+	host, port, err := cp.inner.GetHostPortDefault(key, defaultPort)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetHostPortDefault(key, defaultPort)
+	}
+
+	return host, port, err
+}
+
+func (cp *DefaultingConfigProvider) GetTemplate(key string, funcs ...template.FuncMap) (*template.Template, error) {
+	value, err := extended(cp.inner).GetTemplate(key, funcs...)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetTemplate(key, funcs...)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetMailAddress(key string) (*mail.Address, error) {
+	value, err := extended(cp.inner).GetMailAddress(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetMailAddress(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetMailAddressList(key string) ([]*mail.Address, error) {
+	value, err := extended(cp.inner).GetMailAddressList(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetMailAddressList(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetPath(key string) (string, error) {
+	value, err := extended(cp.inner).GetPath(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetPath(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetCron(key string) (*CronSchedule, error) {
+	value, err := extended(cp.inner).GetCron(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetCron(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetSemver(key string) (Semver, error) {
+	value, err := extended(cp.inner).GetSemver(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetSemver(key)
+	}
+
+	return value, err
+}
+
+func (cp *DefaultingConfigProvider) GetSemverConstraint(key string) (*SemverConstraint, error) {
+	value, err := extended(cp.inner).GetSemverConstraint(key)
+	if isKeyNotFound(err) {
+		return cp.defaults.GetSemverConstraint(key)
+	}
+
+	return value, err
+}
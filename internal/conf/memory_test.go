@@ -0,0 +1,153 @@
+package conf
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestInMemorySetAndDelete(t *testing.T) {
+	data := map[string]string{"port": "8080"}
+	cp := NewInMemoryConfigProvider(data)
+
+	value, err := cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 8080, value, "cp.GetInt value")
+
+	cp.Set("port", "9090")
+	cp.Set("host", "localhost")
+
+	value, err = cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 9090, value, "cp.GetInt value")
+	AssertEquals(t, []string{"host", "port"}, cp.Keys(), "cp.Keys")
+	AssertEquals(t, "8080", data["port"], "data is not modified")
+
+	cp.Delete("port")
+
+	_, err = cp.GetInt("port")
+	AssertEquals(t, NewKeyNotFoundError("port"), err, "cp.GetInt error")
+	AssertEquals(t, false, cp.Has("port"), "cp.Has")
+}
+
+func TestInMemoryNilData(t *testing.T) {
+	cp := NewInMemoryConfigProvider(nil)
+	AssertEquals(t, []string{}, cp.Keys(), "cp.Keys")
+
+	cp.Set("key", "value")
+	value, err := cp.GetString("key")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "value", value, "cp.GetString value")
+}
+
+func TestInMemoryOverridesFile(t *testing.T) {
+	override := NewInMemoryConfigProvider(nil)
+	cp := NewChainConfigProvider([]ConfigProvider{
+		override,
+		NewFileConfigProvider(testFile),
+	})
+
+	value, err := cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 42, value, "cp.GetInt value")
+
+	override.Set("int", "7")
+	value, err = cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 7, value, "cp.GetInt value")
+}
+
+func TestInMemoryConcurrentSetAndGet(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{"port": "8080"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			cp.Set("port", strconv.Itoa(9000+i))
+			cp.Set("worker."+strconv.Itoa(i), "ready")
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := cp.GetInt("port")
+			AssertEquals(t, nil, err, "cp.GetInt error")
+		}()
+		go func() {
+			defer wg.Done()
+			all, err := cp.All()
+			AssertEquals(t, nil, err, "cp.All error")
+			AssertEquals(t, true, len(all) >= 1, "len(all)")
+			cp.Keys()
+		}()
+	}
+	wg.Wait()
+
+	AssertEquals(t, 21, len(cp.Keys()), "len(cp.Keys)")
+}
+
+func TestDefaultingConfigProvider(t *testing.T) {
+	cp := NewDefaultingConfigProvider(NewFileConfigProvider(testFile), map[string]string{
+		"int":          "7",
+		"default.port": "8080",
+	})
+
+	value, err := cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 42, value, "cp.GetInt value")
+
+	value, err = cp.GetInt("default.port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 8080, value, "cp.GetInt value")
+
+	_, err = cp.GetInt("missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "cp.GetInt error")
+
+	_, err = cp.GetBool("int")
+	conversionErr, ok := err.(*TypeConversionError)
+	AssertEquals(t, true, ok, "cp.GetBool TypeConversionError")
+	AssertEquals(t, NewTypeConversionError("int", "42", "bool"), conversionErr, "cp.GetBool error")
+
+	keys := cp.Keys()
+	AssertEquals(t, true, len(keys) > 2, "len(cp.Keys)")
+	AssertEquals(t, true, cp.Has("default.port"), "cp.Has default")
+	all, err := cp.All()
+	AssertEquals(t, nil, err, "cp.All error")
+	AssertEquals(t, "42", all["int"], "cp.All inner value")
+	AssertEquals(t, "8080", all["default.port"], "cp.All default value")
+}
+
+func TestDefaultingConfigProviderWithDefault(t *testing.T) {
+	cp := NewDefaultingConfigProvider(NewFileConfigProvider(testFile), nil).
+		WithDefault("int", "7").
+		WithDefault("default.timeout", "5s")
+
+	value, err := cp.GetInt("int")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 42, value, "cp.GetInt value")
+
+	timeout, err := cp.GetDuration("default.timeout")
+	AssertEquals(t, nil, err, "cp.GetDuration error")
+	AssertEquals(t, 5*time.Second, timeout, "cp.GetDuration value")
+}
+
+func TestDefaultingConfigProviderInnerChainConversionError(t *testing.T) {
+	t.Setenv("SOLVENT_DEFAULTING_OTHER", "1")
+	inner := NewChainConfigProvider([]ConfigProvider{
+		NewEnvConfigProvider("SOLVENT_DEFAULTING_"),
+		NewInMemoryConfigProvider(map[string]string{"port": "notanumber"}),
+	})
+	cp := NewDefaultingConfigProvider(inner, map[string]string{"port": "8080"})
+
+	_, err := cp.GetInt("port")
+	AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion")
+	AssertEquals(t, false, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound")
+
+	var provider ConfigProvider = cp
+	_, canRemove := provider.(interface{ Remove(index int) })
+	AssertEquals(t, false, canRemove, "DefaultingConfigProvider exposes Remove")
+}
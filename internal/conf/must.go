@@ -0,0 +1,311 @@
+package conf
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MustGetString is like GetString but panics with a MustGetError. It
+// is meant for keys that are mandatory at startup
+func (cp *FileConfigProvider) MustGetString(key string) string {
+	value, err := cp.GetString(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetFloat is like GetFloat but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetFloat(key string) float64 {
+	value, err := cp.GetFloat(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetBool is like GetBool but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetBool(key string) bool {
+	value, err := cp.GetBool(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetInt is like GetInt but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetInt(key string) int {
+	value, err := cp.GetInt(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetInt64 is like GetInt64 but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetInt64(key string) int64 {
+	value, err := cp.GetInt64(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetUint is like GetUint but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetUint(key string) uint {
+	value, err := cp.GetUint(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetDuration is like GetDuration but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetDuration(key string) time.Duration {
+	value, err := cp.GetDuration(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetTime is like GetTime but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetTime(key string) time.Time {
+	value, err := cp.GetTime(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetStringSlice is like GetStringSlice but panics with a
+// MustGetError
+func (cp *FileConfigProvider) MustGetStringSlice(key string) []string {
+	value, err := cp.GetStringSlice(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetIntSlice is like GetIntSlice but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetIntSlice(key string) []int {
+	value, err := cp.GetIntSlice(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetFloatSlice is like GetFloatSlice but panics with a
+// MustGetError
+func (cp *FileConfigProvider) MustGetFloatSlice(key string) []float64 {
+	value, err := cp.GetFloatSlice(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetLocation is like GetLocation but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetLocation(key string) *time.Location {
+	value, err := cp.GetLocation(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetURL is like GetURL but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetURL(key string, schemes ...string) *url.URL {
+	value, err := cp.GetURL(key, schemes...)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetBytes is like GetBytes but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetBytes(key string, encoding ...Encoding) []byte {
+	value, err := cp.GetBytes(key, encoding...)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetIP is like GetIP but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetIP(key string) net.IP {
+	value, err := cp.GetIP(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetCIDR is like GetCIDR but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetCIDR(key string) *net.IPNet {
+	value, err := cp.GetCIDR(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetRegexp is like GetRegexp but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetRegexp(key string) *regexp.Regexp {
+	value, err := cp.GetRegexp(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetSize is like GetSize but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetSize(key string) int64 {
+	value, err := cp.GetSize(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetRateLimit is like GetRateLimit but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetRateLimit(key string) RateLimit {
+	value, err := cp.GetRateLimit(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetPort is like GetPort but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetPort(key string) int {
+	value, err := cp.GetPort(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetLogLevel is like GetLogLevel but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetLogLevel(key string) slog.Level {
+	value, err := cp.GetLogLevel(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetEnum is like GetEnum but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetEnum(key string, allowed ...string) string {
+	value, err := cp.GetEnum(key, allowed...)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetTemplate is like GetTemplate but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetTemplate(key string, funcs ...template.FuncMap) *template.Template {
+	value, err := cp.GetTemplate(key, funcs...)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetMailAddress is like GetMailAddress but panics with a
+// MustGetError
+func (cp *FileConfigProvider) MustGetMailAddress(key string) *mail.Address {
+	value, err := cp.GetMailAddress(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetMailAddressList is like GetMailAddressList but panics with a
+// MustGetError
+func (cp *FileConfigProvider) MustGetMailAddressList(key string) []*mail.Address {
+	value, err := cp.GetMailAddressList(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetPath is like GetPath but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetPath(key string) string {
+	value, err := cp.GetPath(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetUUID is like GetUUID but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetUUID(key string) uuid.UUID {
+	value, err := cp.GetUUID(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetCron is like GetCron but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetCron(key string) *CronSchedule {
+	value, err := cp.GetCron(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetSemver is like GetSemver but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetSemver(key string) Semver {
+	value, err := cp.GetSemver(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetSemverConstraint is like GetSemverConstraint but panics with a
+// MustGetError
+func (cp *FileConfigProvider) MustGetSemverConstraint(key string) *SemverConstraint {
+	value, err := cp.GetSemverConstraint(key)
+	cp.must(key, err)
+
+	return value
+}
+
+// MustGetHostPort is like GetHostPort but panics with a MustGetError
+func (cp *FileConfigProvider) MustGetHostPort(key string) (string, int) {
+	host, port, err := cp.GetHostPort(key)
+	cp.must(key, err)
+
+	return host, port
+}
+
+// MustGetHostPortDefault is like GetHostPortDefault but panics with a
+// MustGetError
+func (cp *FileConfigProvider) MustGetHostPortDefault(key string, defaultPort int) (string, int) {
+	host, port, err := cp.GetHostPortDefault(key, defaultPort)
+	cp.must(key, err)
+
+	return host, port
+}
+
+// MustGetError is the panic value of the MustGet* methods of a
+// FileConfigProvider. It wraps the error of the getter so recovering
+// callers can still inspect it with errors.As
+type MustGetError struct {
+	Key     string
+	Path    string
+	err     error
+	message string
+}
+
+func NewMustGetError(key, path string, err error) *MustGetError {
+	return &MustGetError{
+		Key:     key,
+		Path:    path,
+		err:     err,
+		message: fmt.Sprintf("required config value with key '%s' from file '%s' is not usable: %s", key, path, err.Error()),
+	}
+}
+
+func (e *MustGetError) Error() string {
+	return e.message
+}
+
+func (e *MustGetError) Unwrap() error {
+	return e.err
+}
+
+// must panics with a MustGetError if the getter for key failed
+func (cp *FileConfigProvider) must(key string, err error) {
+	if err != nil {
+		panic(NewMustGetError(key, cp.path, err))
+	}
+}
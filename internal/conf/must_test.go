@@ -0,0 +1,69 @@
+package conf
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func recoverPanic(f func()) (value any) {
+	defer func() {
+		value = recover()
+	}()
+	f()
+
+	return nil
+}
+
+func TestFileMustGet(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	AssertEquals(t, "value", cp.MustGetString("string"), "cp.MustGetString")
+	AssertEquals(t, 3.14, cp.MustGetFloat("float"), "cp.MustGetFloat")
+	AssertEquals(t, true, cp.MustGetBool("bool"), "cp.MustGetBool")
+	AssertEquals(t, 42, cp.MustGetInt("int"), "cp.MustGetInt")
+	AssertEquals(t, int64(42), cp.MustGetInt64("int"), "cp.MustGetInt64")
+	AssertEquals(t, 8080, cp.MustGetPort("port"), "cp.MustGetPort")
+	AssertEquals(t, int64(64<<20), cp.MustGetSize("size"), "cp.MustGetSize")
+	AssertEquals(t, slog.LevelDebug, cp.MustGetLogLevel("log.level"), "cp.MustGetLogLevel")
+	AssertEquals(t, "example.com:8443", cp.MustGetURL("url", "https").Host, "cp.MustGetURL")
+
+	host, port := cp.MustGetHostPort("url.hostPort")
+	AssertEquals(t, "localhost", host, "cp.MustGetHostPort host")
+	AssertEquals(t, 8080, port, "cp.MustGetHostPort port")
+}
+
+func TestFileMustGetPanics(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	tests := []struct {
+		name     string
+		get      func()
+		expected error
+	}{
+		{"MustGetString", func() { cp.MustGetString("missing") }, NewKeyNotFoundError("missing")},
+		{"MustGetFloat", func() { cp.MustGetFloat("string") }, NewTypeConversionError("string", "value", "float64")},
+		{"MustGetBool", func() { cp.MustGetBool("int") }, NewTypeConversionError("int", "42", "bool")},
+		{"MustGetPort", func() { cp.MustGetPort("missing") }, NewKeyNotFoundError("missing")},
+		{"MustGetSemver", func() { cp.MustGetSemver("missing") }, NewKeyNotFoundError("missing")},
+		{"MustGetHostPort", func() { cp.MustGetHostPort("missing") }, NewKeyNotFoundError("missing")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err, ok := recoverPanic(test.get).(error)
+			AssertEquals(t, true, ok, "panic value is an error")
+
+			var mustErr *MustGetError
+			AssertEquals(t, true, errors.As(err, &mustErr), "errors.As MustGetError")
+			AssertEquals(t, cp.path, mustErr.Path, "mustErr.Path")
+			AssertEquals(t, test.expected, errors.Unwrap(err), "errors.Unwrap")
+		})
+	}
+
+	err := recoverPanic(func() { cp.MustGetString("missing") }).(error)
+	AssertEquals(t, "required config value with key 'missing' from file '"+cp.path+
+		"' is not usable: config value with key 'missing' could not be found", err.Error(), "err.Error")
+}
@@ -0,0 +1,274 @@
+package conf
+
+import (
+	"strings"
+	"time"
+)
+
+// Option configures the optional behaviour of a ConfigProvider
+type Option func(*options)
+
+type options struct {
+	timeLayouts    []string
+	separator      string
+	quotedElements bool
+	relativeURLs   bool
+	inlineComments bool
+	posixRegexps   bool
+	trimSpace      bool
+	zeroPort       bool
+
+	caseInsensitiveEnums bool
+	caseInsensitiveKeys  bool
+
+	interpolation      bool
+	envInterpolation   bool
+	interpolationDepth int
+
+	allParsingErrors bool
+	existingPaths    bool
+	lenientBools     bool
+	strictDecimal    bool
+
+	delimiter       byte
+	commentPrefixes []string
+
+	reloadCheckInterval time.Duration
+}
+
+// defaultReloadCheckInterval is how often file based document providers
+// check whether their file was modified
+const defaultReloadCheckInterval = time.Second
+
+func newOptions(opts []Option) options {
+	o := options{
+		timeLayouts:    []string{time.RFC3339},
+		separator:      ",",
+		inlineComments: true,
+		trimSpace:      true,
+
+		delimiter:       '=',
+		commentPrefixes: []string{"#", ";"},
+
+		interpolationDepth: defaultInterpolationDepth,
+
+		reloadCheckInterval: defaultReloadCheckInterval,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithTimeLayouts registers additional layouts that GetTime tries in
+// the given order after RFC3339
+func WithTimeLayouts(layouts ...string) Option {
+	return func(o *options) {
+		o.timeLayouts = append(o.timeLayouts, layouts...)
+	}
+}
+
+// WithSeparator changes the separator GetStringSlice splits values on
+// (default ','). A whitespace-only separator splits on any run of
+// whitespace
+func WithSeparator(separator string) Option {
+	return func(o *options) {
+		o.separator = separator
+	}
+}
+
+// WithQuotedElements lets GetStringSlice keep elements wrapped in
+// double quotes intact even if they contain the separator
+func WithQuotedElements() Option {
+	return func(o *options) {
+		o.quotedElements = true
+	}
+}
+
+// WithRelativeURLs lets GetURL accept values without scheme and host
+func WithRelativeURLs() Option {
+	return func(o *options) {
+		o.relativeURLs = true
+	}
+}
+
+// WithoutInlineComments keeps trailing '# ...' and '; ...' parts of a
+// line as part of the value instead of treating them as comments
+func WithoutInlineComments() Option {
+	return func(o *options) {
+		o.inlineComments = false
+	}
+}
+
+// WithPOSIXRegexps compiles the values of GetRegexp with POSIX ERE syntax
+// and leftmost-longest matching
+func WithPOSIXRegexps() Option {
+	return func(o *options) {
+		o.posixRegexps = true
+	}
+}
+
+// WithTrimSpace controls whether the whitespace surrounding unquoted
+// values is removed (default true). Keys are always trimmed
+func WithTrimSpace(trim bool) Option {
+	return func(o *options) {
+		o.trimSpace = trim
+	}
+}
+
+// WithZeroPort lets GetPort accept 0 which usually means that any free
+// port may be picked
+func WithZeroPort() Option {
+	return func(o *options) {
+		o.zeroPort = true
+	}
+}
+
+// WithCaseInsensitiveEnums lets GetEnum match the allowed values
+// regardless of their case
+func WithCaseInsensitiveEnums() Option {
+	return func(o *options) {
+		o.caseInsensitiveEnums = true
+	}
+}
+
+// WithCaseInsensitiveKeys stores and looks up keys in lowercase so
+// 'Port' and 'port' refer to the same value. Keys that only differ in
+// case result in a ParsingError when the config is loaded. Applies to
+// file based providers
+func WithCaseInsensitiveKeys() Option {
+	return func(o *options) {
+		o.caseInsensitiveKeys = true
+	}
+}
+
+// WithInterpolation expands '${key}' references in values of a
+// FileConfigProvider with the values of other keys of the same file.
+// '$$' results in a literal '$'
+func WithInterpolation() Option {
+	return func(o *options) {
+		o.interpolation = true
+	}
+}
+
+// WithEnvInterpolation is like WithInterpolation but falls back to
+// environment variables for references to keys missing in the file
+func WithEnvInterpolation() Option {
+	return func(o *options) {
+		o.interpolation = true
+		o.envInterpolation = true
+	}
+}
+
+// WithInterpolationDepth limits how deeply interpolated references may
+// be nested (default 10)
+func WithInterpolationDepth(depth int) Option {
+	return func(o *options) {
+		o.interpolationDepth = depth
+	}
+}
+
+// WithAllParsingErrors keeps parsing after a malformed line and reports
+// all of them at once as ParsingErrors instead of stopping at the first
+func WithAllParsingErrors() Option {
+	return func(o *options) {
+		o.allParsingErrors = true
+	}
+}
+
+// WithExistingPaths lets GetPath fail with a PathNotFoundError if
+// nothing exists at the resolved path
+func WithExistingPaths() Option {
+	return func(o *options) {
+		o.existingPaths = true
+	}
+}
+
+// WithLenientBool lets GetBool additionally accept yes/no, y/n, on/off
+// and enabled/disabled regardless of their case
+func WithLenientBool() Option {
+	return func(o *options) {
+		o.lenientBools = true
+	}
+}
+
+// WithStrictDecimal makes GetInt, GetInt64, GetUint and GetIntSlice
+// reject base prefixes and digit separators and only accept plain
+// decimal values
+func WithStrictDecimal() Option {
+	return func(o *options) {
+		o.strictDecimal = true
+	}
+}
+
+// WithDelimiter changes the byte that separates keys from values in
+// properties files (default '='), e.g. ':' or '\t'
+func WithDelimiter(delimiter byte) Option {
+	return func(o *options) {
+		o.delimiter = delimiter
+	}
+}
+
+// WithCommentPrefix replaces the prefixes that start a comment in
+// properties files (default '#' and ';') with the given one
+func WithCommentPrefix(prefix string) Option {
+	return func(o *options) {
+		o.commentPrefixes = []string{prefix}
+	}
+}
+
+// WithReloadCheckInterval changes how often the JSON, TOML, YAML and
+// dotenv providers check the modification time of their files to reload
+// them (default one second). Zero checks on every access
+func WithReloadCheckInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.reloadCheckInterval = interval
+	}
+}
+
+// withOptions replaces all options with o so a provider can be created
+// with the options of another one
+func withOptions(o options) Option {
+	return func(target *options) {
+		*target = o
+	}
+}
+
+// optionsProvider is implemented by the providers of the package to
+// expose the options they were created with
+type optionsProvider interface {
+	providerOptions() (options, bool)
+}
+
+// optionsOf returns the options cp was created with or the default ones
+// for providers without options
+func optionsOf(cp ConfigProvider) options {
+	if p, ok := cp.(optionsProvider); ok {
+		if o, ok := p.providerOptions(); ok {
+			return o
+		}
+	}
+
+	return newOptions(nil)
+}
+
+func (o options) normalizeKey(key string) string {
+	if o.caseInsensitiveKeys {
+		return strings.ToLower(key)
+	}
+
+	return key
+}
+
+// isCommentStart reports whether s starts with one of the comment
+// prefixes
+func (o options) isCommentStart(s string) bool {
+	for _, prefix := range o.commentPrefixes {
+		if prefix != "" && strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
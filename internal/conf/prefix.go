@@ -0,0 +1,264 @@
+package conf
+
+import (
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PrefixConfigProvider scopes another provider to the keys starting
+// with a fixed prefix so a subsystem can look up 'host' instead of
+// 'db.host'
+type PrefixConfigProvider struct {
+	prefix string
+	inner  ConfigProvider
+}
+
+// NewPrefixConfigProvider creates a new PrefixConfigProvider that
+// prepends the prefix (e.g. 'db.') to every key looked up in inner
+func NewPrefixConfigProvider(prefix string, inner ConfigProvider) *PrefixConfigProvider {
+	return &PrefixConfigProvider{
+		prefix: prefix,
+		inner:  inner,
+	}
+}
+
+// Keys returns the sorted keys of inner that start with the prefix with
+// the prefix removed
+func (cp *PrefixConfigProvider) Keys() []string {
+	keys := []string{}
+	for _, key := range cp.inner.Keys() {
+		if strings.HasPrefix(key, cp.prefix) && key != cp.prefix {
+			keys = append(keys, strings.TrimPrefix(key, cp.prefix))
+		}
+	}
+
+	return keys
+}
+
+// All returns the values of inner whose keys start with the prefix with
+// the prefix removed
+func (cp *PrefixConfigProvider) All() (map[string]string, error) {
+	values, err := cp.inner.All()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]string{}
+	for key, value := range values {
+		if strings.HasPrefix(key, cp.prefix) && key != cp.prefix {
+			result[strings.TrimPrefix(key, cp.prefix)] = value
+		}
+	}
+
+	return result, nil
+}
+
+func (cp *PrefixConfigProvider) Has(key string) bool {
+	return cp.inner.Has(cp.prefix + key)
+}
+
+func (cp *PrefixConfigProvider) HasErr(key string) (bool, error) {
+	return cp.inner.HasErr(cp.prefix + key)
+}
+
+func (cp *PrefixConfigProvider) LookupString(key string) (string, bool) {
+	return cp.inner.LookupString(cp.prefix + key)
+}
+
+func (cp *PrefixConfigProvider) LookupBool(key string) (bool, bool) {
+	return cp.inner.LookupBool(cp.prefix + key)
+}
+
+func (cp *PrefixConfigProvider) LookupFloat(key string) (float64, bool) {
+	return cp.inner.LookupFloat(cp.prefix + key)
+}
+
+func (cp *PrefixConfigProvider) GetString(key string) (string, error) {
+	value, err := cp.inner.GetString(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetFloat(key string) (float64, error) {
+	value, err := cp.inner.GetFloat(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetBool(key string) (bool, error) {
+	value, err := cp.inner.GetBool(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetInt(key string) (int, error) {
+	value, err := cp.inner.GetInt(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetInt64(key string) (int64, error) {
+	value, err := cp.inner.GetInt64(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetUint(key string) (uint, error) {
+	value, err := cp.inner.GetUint(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetDuration(key string) (time.Duration, error) {
+	value, err := cp.inner.GetDuration(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetTime(key string) (time.Time, error) {
+	value, err := cp.inner.GetTime(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetStringSlice(key string) ([]string, error) {
+	value, err := cp.inner.GetStringSlice(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetIntSlice(key string) ([]int, error) {
+	value, err := cp.inner.GetIntSlice(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetFloatSlice(key string) ([]float64, error) {
+	value, err := cp.inner.GetFloatSlice(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetURL(key string, schemes ...string) (*url.URL, error) {
+	value, err := cp.inner.GetURL(cp.prefix+key, schemes...)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetBytes(key string, encoding ...Encoding) ([]byte, error) {
+	value, err := cp.inner.GetBytes(cp.prefix+key, encoding...)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetIP(key string) (net.IP, error) {
+	value, err := cp.inner.GetIP(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetCIDR(key string) (*net.IPNet, error) {
+	value, err := cp.inner.GetCIDR(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetRegexp(key string) (*regexp.Regexp, error) {
+	value, err := cp.inner.GetRegexp(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetSize(key string) (int64, error) {
+	value, err := cp.inner.GetSize(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetRateLimit(key string) (RateLimit, error) {
+	value, err := extended(cp.inner).GetRateLimit(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetPort(key string) (int, error) {
+	value, err := cp.inner.GetPort(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetLogLevel(key string) (slog.Level, error) {
+	value, err := cp.inner.GetLogLevel(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetEnum(key string, allowed ...string) (string, error) {
+	value, err := cp.inner.GetEnum(cp.prefix+key, allowed...)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetStruct(key string, out any) error {
+	return cp.unprefixed(key, cp.inner.GetStruct(cp.prefix+key, out))
+}
+
+func (cp *PrefixConfigProvider) GetLocation(key string) (*time.Location, error) {
+	value, err := cp.inner.GetLocation(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetUUID(key string) (uuid.UUID, error) {
+	value, err := extended(cp.inner).GetUUID(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetHostPort(key string) (string, int, error) {
+	host, port, err := cp.inner.GetHostPort(cp.prefix + key)
+	return host, port, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetHostPortDefault(key string, defaultPort int) (string, int, error) {
+	host, port, err := cp.inner.GetHostPortDefault(cp.prefix+key, defaultPort)
+	return host, port, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetTemplate(key string, funcs ...template.FuncMap) (*template.Template, error) {
+	value, err := extended(cp.inner).GetTemplate(cp.prefix+key, funcs...)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetMailAddress(key string) (*mail.Address, error) {
+	value, err := extended(cp.inner).GetMailAddress(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetMailAddressList(key string) ([]*mail.Address, error) {
+	value, err := extended(cp.inner).GetMailAddressList(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetPath(key string) (string, error) {
+	value, err := extended(cp.inner).GetPath(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetCron(key string) (*CronSchedule, error) {
+	value, err := extended(cp.inner).GetCron(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetSemver(key string) (Semver, error) {
+	value, err := extended(cp.inner).GetSemver(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) GetSemverConstraint(key string) (*SemverConstraint, error) {
+	value, err := extended(cp.inner).GetSemverConstraint(cp.prefix + key)
+	return value, cp.unprefixed(key, err)
+}
+
+func (cp *PrefixConfigProvider) providerOptions() (options, bool) {
+	if p, ok := cp.inner.(optionsProvider); ok {
+		return p.providerOptions()
+	}
+
+	return options{}, false
+}
+
+// unprefixed reports a missing key with the key the caller asked for
+// instead of the prefixed one
+func (cp *PrefixConfigProvider) unprefixed(key string, err error) error {
+	if isKeyNotFound(err) {
+		return NewKeyNotFoundError(key)
+	}
+
+	return err
+}
@@ -0,0 +1,78 @@
+package conf
+
+import (
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestPrefixConfigProvider(t *testing.T) {
+	inner := NewInMemoryConfigProvider(map[string]string{
+		"db.host":     "localhost",
+		"db.port":     "5432",
+		"db.timeout":  "5s",
+		"server.port": "8080",
+		"db":          "ignored",
+	})
+	cp := NewPrefixConfigProvider("db.", inner)
+
+	host, err := cp.GetString("host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "localhost", host, "cp.GetString value")
+
+	port, err := cp.GetPort("port")
+	AssertEquals(t, nil, err, "cp.GetPort error")
+	AssertEquals(t, 5432, port, "cp.GetPort value")
+
+	_, err = cp.GetString("user")
+	AssertEquals(t, NewKeyNotFoundError("user"), err, "cp.GetString error")
+
+	_, err = cp.GetBool("host")
+	AssertEquals(t, NewTypeConversionError("db.host", "localhost", "bool"), err, "cp.GetBool error")
+
+	AssertEquals(t, []string{"host", "port", "timeout"}, cp.Keys(), "cp.Keys")
+	AssertEquals(t, true, cp.Has("timeout"), "cp.Has timeout")
+	AssertEquals(t, false, cp.Has("server.port"), "cp.Has server.port")
+}
+
+func TestPrefixInChain(t *testing.T) {
+	t.Setenv("SOLVENT_TEST_DB_HOST", "db.internal")
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewPrefixConfigProvider("db.", NewEnvConfigProvider("SOLVENT_TEST_")),
+		NewPrefixConfigProvider("db.", NewInMemoryConfigProvider(map[string]string{
+			"db.host": "localhost",
+			"db.port": "5432",
+		})),
+	})
+
+	host, err := cp.GetString("host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "db.internal", host, "cp.GetString value")
+
+	port, err := cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 5432, port, "cp.GetInt value")
+
+	_, err = cp.GetDuration("timeout")
+	AssertEquals(t, NewKeyNotFoundError("timeout"), err, "cp.GetDuration error")
+}
+
+func TestPrefixReusesStructDecoding(t *testing.T) {
+	type endpoint struct {
+		Host string `conf:"host"`
+		Port int    `conf:"port"`
+	}
+	global := NewInMemoryConfigProvider(map[string]string{
+		"database_host": "db.internal",
+		"database_port": "5432",
+		"cache_host":    "cache.internal",
+		"cache_port":    "6379",
+	})
+
+	var database, cache endpoint
+	AssertEquals(t, nil, Decode(NewPrefixConfigProvider("database_", global), &database), "Decode database error")
+	AssertEquals(t, nil, Decode(NewPrefixConfigProvider("cache_", global), &cache), "Decode cache error")
+
+	AssertEquals(t, endpoint{"db.internal", 5432}, database, "database endpoint")
+	AssertEquals(t, endpoint{"cache.internal", 6379}, cache, "cache endpoint")
+}
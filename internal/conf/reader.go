@@ -0,0 +1,65 @@
+package conf
+
+import (
+	"bytes"
+	"io"
+)
+
+// ReaderConfigProvider reads config values in the format of
+// FileConfigProvider from memory instead of a file
+type ReaderConfigProvider struct {
+	typedGetters
+	doc *document
+}
+
+// NewReaderConfigProvider creates a new ReaderConfigProvider that lazily
+// reads its config from the given reader
+func NewReaderConfigProvider(r io.Reader, opts ...Option) *ReaderConfigProvider {
+	cp := &ReaderConfigProvider{}
+	cp.doc = newReaderDocument(r, cp.parse)
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+
+	return cp
+}
+
+// NewBytesConfigProvider creates a new ReaderConfigProvider that reads
+// its config from the given bytes
+func NewBytesConfigProvider(b []byte, opts ...Option) *ReaderConfigProvider {
+	return NewReaderConfigProvider(bytes.NewReader(b), opts...)
+}
+
+func (cp *ReaderConfigProvider) GetString(key string) (string, error) {
+	store, err := cp.doc.loadStore()
+	if err != nil {
+		return "", err
+	}
+
+	return lookupProperty(store, key, cp.options)
+}
+
+// All returns a copy of all values
+func (cp *ReaderConfigProvider) All() (map[string]string, error) {
+	store, err := cp.doc.loadStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return allProperties(store, cp.options)
+}
+
+// Keys returns the sorted keys or nil if the config cannot be read
+func (cp *ReaderConfigProvider) Keys() []string {
+	return cp.doc.keys()
+}
+
+func (cp *ReaderConfigProvider) parse(r io.Reader) (map[string]string, error) {
+	store, err := parseProperties(r, cp.options)
+	if err != nil && !isParsingError(err) {
+		err = &UnknownError{
+			err:     err,
+			message: "could not read config",
+		}
+	}
+
+	return store, err
+}
@@ -0,0 +1,42 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestReaderConfigProvider(t *testing.T) {
+	cp := NewReaderConfigProvider(strings.NewReader("# comment\nport=8080\n[db]\nhost = localhost\n"))
+
+	port, err := cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 8080, port, "cp.GetInt value")
+
+	host, err := cp.GetString("db.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "localhost", host, "cp.GetString value")
+
+	_, err = cp.GetString("missing")
+	AssertEquals(t, NewKeyNotFoundError("missing"), err, "cp.GetString error")
+	AssertEquals(t, []string{"db.host", "port"}, cp.Keys(), "cp.Keys")
+}
+
+func TestBytesConfigProvider(t *testing.T) {
+	cp := NewBytesConfigProvider([]byte("host=localhost\nurl=http://${host}\n"), WithInterpolation())
+
+	url, err := cp.GetString("url")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "http://localhost", url, "cp.GetString value")
+}
+
+func TestReaderConfigProviderMalformed(t *testing.T) {
+	cp := NewBytesConfigProvider([]byte("valid=1\ninvalid\n"))
+
+	_, err := cp.GetString("valid")
+	AssertEquals(t, NewParsingErrorAt(2, "invalid"), err, "cp.GetString error")
+
+	_, err = cp.GetString("valid")
+	AssertEquals(t, NewParsingErrorAt(2, "invalid"), err, "cp.GetString error on retry")
+}
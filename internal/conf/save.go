@@ -0,0 +1,249 @@
+package conf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// renameFile is replaced in tests to simulate failing writes
+var renameFile = os.Rename
+
+// Set changes the value of the given key in memory. The change is
+// visible to all getters immediately and written to the file by Save
+func (cp *FileConfigProvider) Set(key, value string) {
+	key = cp.options.normalizeKey(key)
+
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	if cp.changes == nil {
+		cp.changes = map[string]string{}
+	}
+	cp.changes[key] = value
+
+	if cp.store != nil {
+		// Copy on write so callers iterating over an earlier store are
+		// not affected
+		cp.store = applyChanges(cp.store, map[string]string{key: value})
+	}
+}
+
+// Save writes the values changed with Set back to the file. Lines of
+// existing keys are updated in place so comments, blank lines and the
+// order of keys are kept, new keys are appended in sorted order. The
+// file is replaced atomically and left untouched if anything fails
+func (cp *FileConfigProvider) Save() error {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	content, err := os.ReadFile(cp.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return &UnknownError{
+			err:     err,
+			message: fmt.Sprintf("could not read from file with path '%s'", cp.path),
+		}
+	}
+
+	updated, err := updateProperties(content, cp.changes, cp.options)
+	if err != nil {
+		return err
+	}
+
+	store, err := parseProperties(bytes.NewReader(updated), cp.options)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomically(cp.path, updated); err != nil {
+		return err
+	}
+
+	cp.store = store
+	cp.changes = nil
+
+	return nil
+}
+
+// updateProperties replaces the values of the changed keys in content
+// and appends the keys that are not part of it yet
+func updateProperties(content []byte, changes map[string]string, o options) ([]byte, error) {
+	var result bytes.Buffer
+	written := map[string]bool{}
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		bom := ""
+		if lineNumber == 1 && strings.HasPrefix(line, "\ufeff") {
+			bom = "\ufeff"
+			line = strings.TrimPrefix(line, bom)
+		}
+
+		name, isSection := sectionHeader(line)
+		delimiter := string(o.delimiter)
+		tokens := strings.SplitN(line, delimiter, 2)
+		switch {
+		case isBlankOrComment(line, o):
+		case isSection:
+			section = name
+		case len(tokens) == 2:
+			key := o.normalizeKey(joinKey(section, strings.TrimSpace(tokens[0])))
+			if value, ok := changes[key]; ok {
+				formatted, err := formatPropertyValue(key, value, o)
+				if err != nil {
+					return nil, err
+				}
+				line = tokens[0] + delimiter + replaceRawValue(tokens[1], formatted, o)
+				written[key] = true
+			}
+		}
+
+		result.WriteString(bom + line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	newKeys := []string{}
+	for key := range changes {
+		if !written[key] {
+			newKeys = append(newKeys, key)
+		}
+	}
+	sort.Strings(newKeys)
+
+	if len(newKeys) > 0 && section != "" {
+		// New keys are not part of the last section of the file
+		result.WriteString("[]\n")
+	}
+	for _, key := range newKeys {
+		if !isValidPropertyKey(key, o) {
+			return nil, &UnknownError{
+				message: fmt.Sprintf("key '%s' cannot be written to a properties file", key),
+			}
+		}
+
+		formatted, err := formatPropertyValue(key, changes[key], o)
+		if err != nil {
+			return nil, err
+		}
+		result.WriteString(key + string(o.delimiter) + formatted + "\n")
+	}
+
+	return result.Bytes(), nil
+}
+
+// replaceRawValue swaps the value of a raw 'key=value' right-hand side
+// while keeping the surrounding whitespace and an inline comment
+func replaceRawValue(raw, formatted string, o options) string {
+	leading := raw[:len(raw)-len(strings.TrimLeft(raw, " \t"))]
+	if !o.inlineComments {
+		return leading + formatted
+	}
+
+	value := stripInlineComment(raw, o)
+	comment := raw[len(value):]
+	if comment == "" {
+		return leading + formatted
+	}
+	trailing := value[len(strings.TrimRight(value, " \t")):]
+
+	return leading + formatted + trailing + comment
+}
+
+// formatPropertyValue returns value in a form that reads back unchanged,
+// quoting it if necessary
+func formatPropertyValue(key, value string, o options) (string, error) {
+	if !strings.ContainsAny(value, "\r\n") {
+		if parseValue(value, o) == value {
+			return value, nil
+		}
+		for _, quote := range []string{`"`, "'"} {
+			quoted := quote + value + quote
+			if !strings.Contains(value, quote) && parseValue(quoted, o) == value {
+				return quoted, nil
+			}
+		}
+	}
+
+	return "", &UnknownError{
+		message: fmt.Sprintf("value '%s' of key '%s' cannot be written to a properties file", value, key),
+	}
+}
+
+func isValidPropertyKey(key string, o options) bool {
+	if strings.TrimSpace(key) != key || strings.ContainsAny(key, string(o.delimiter)+"\r\n") {
+		return false
+	}
+	_, isSection := sectionHeader(key)
+
+	return !isBlankOrComment(key, o) && !isSection
+}
+
+// writeFileAtomically writes data to a temporary file next to path and
+// renames it over path so readers never see a partially written file
+func writeFileAtomically(path string, data []byte) error {
+	mode := fs.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return &UnknownError{
+			err:     err,
+			message: fmt.Sprintf("could not create temporary file for path '%s'", path),
+		}
+	}
+	tmpPath := file.Name()
+
+	err = writeAndClose(file, data, mode)
+	if err == nil {
+		err = renameFile(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return &UnknownError{
+			err:     err,
+			message: fmt.Sprintf("could not save file with path '%s'", path),
+		}
+	}
+
+	return nil
+}
+
+func writeAndClose(file *os.File, data []byte, mode fs.FileMode) error {
+	_, err := file.Write(data)
+	if err == nil {
+		err = file.Chmod(mode)
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// applyChanges returns a copy of store with changes applied
+func applyChanges(store, changes map[string]string) map[string]string {
+	if len(changes) == 0 {
+		return store
+	}
+
+	result := copyMap(store)
+	for key, value := range changes {
+		result[key] = value
+	}
+
+	return result
+}
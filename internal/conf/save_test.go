@@ -0,0 +1,140 @@
+package conf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestSetAndSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.properties")
+	writeFile(t, path, "# Server settings\nport = 8080 # HTTP only\nhost=localhost\n\n[db]\n; primary\nname=solvent\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	cp.Set("port", "9090")
+	cp.Set("db.name", "solvent test")
+	cp.Set("feature.beta", " padded ")
+
+	value, err := cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 9090, value, "cp.GetInt value before Save")
+
+	AssertEquals(t, nil, cp.Save(), "cp.Save error")
+
+	content, err := os.ReadFile(path)
+	AssertEquals(t, nil, err, "os.ReadFile error")
+	AssertEquals(t, "# Server settings\nport = 9090 # HTTP only\nhost=localhost\n\n[db]\n; primary\nname=solvent test\n[]\nfeature.beta=\" padded \"\n",
+		string(content), "saved content")
+
+	reread := newFileConfigProvider(path, false, nil)
+	AssertEquals(t, cp.Keys(), reread.Keys(), "reread.Keys")
+	for _, key := range cp.Keys() {
+		expected, _ := cp.GetString(key)
+		actual, err := reread.GetString(key)
+		AssertEquals(t, nil, err, "reread.GetString error")
+		AssertEquals(t, expected, actual, "reread.GetString "+key)
+	}
+}
+
+func TestSaveKeepsUnsavedChangesOnReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.properties")
+	writeFile(t, path, "port=8080\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	cp.Set("host", "localhost")
+	writeFile(t, path, "port=9090\n")
+	AssertEquals(t, nil, cp.Reload(), "cp.Reload error")
+
+	host, err := cp.GetString("host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "localhost", host, "cp.GetString value")
+
+	AssertEquals(t, nil, cp.Save(), "cp.Save error")
+	content, err := os.ReadFile(path)
+	AssertEquals(t, nil, err, "os.ReadFile error")
+	AssertEquals(t, "port=9090\nhost=localhost\n", string(content), "saved content")
+}
+
+func TestSaveFailureKeepsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.properties")
+	writeFile(t, path, "port=8080\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	renameErr := errors.New("disk full")
+	renameFile = func(oldPath, newPath string) error {
+		return renameErr
+	}
+	defer func() { renameFile = os.Rename }()
+
+	cp.Set("port", "9090")
+	err := cp.Save()
+	AssertEquals(t, true, errors.Is(err, renameErr), "errors.Is rename error")
+
+	content, err := os.ReadFile(path)
+	AssertEquals(t, nil, err, "os.ReadFile error")
+	AssertEquals(t, "port=8080\n", string(content), "file content")
+
+	entries, err := os.ReadDir(dir)
+	AssertEquals(t, nil, err, "os.ReadDir error")
+	AssertEquals(t, 1, len(entries), "temporary file removed")
+
+	cp.Set("multiline", "a\nb")
+	renameFile = os.Rename
+	AssertEquals(t, true, cp.Save() != nil, "cp.Save error for unwritable value")
+	content, err = os.ReadFile(path)
+	AssertEquals(t, nil, err, "os.ReadFile error")
+	AssertEquals(t, "port=8080\n", string(content), "file content")
+}
+
+func TestConcurrentSetAndGetString(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.properties")
+	writeFile(t, path, "port=8080\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			cp.Set("port", "9090")
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := cp.GetString("port")
+			AssertEquals(t, nil, err, "cp.GetString error")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSaveWithCustomDelimiter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.conf")
+	writeFile(t, path, "// Server settings\nport: 8080 // HTTP only\n")
+	cp := newFileConfigProvider(path, false, []Option{WithDelimiter(':'), WithCommentPrefix("//")})
+
+	cp.Set("port", "9090")
+	cp.Set("host", "localhost")
+	AssertEquals(t, nil, cp.Save(), "cp.Save error")
+
+	content, err := os.ReadFile(path)
+	AssertEquals(t, nil, err, "os.ReadFile error")
+	AssertEquals(t, "// Server settings\nport: 9090 // HTTP only\nhost:localhost\n", string(content), "saved content")
+}
+
+func TestSaveValueStartingWithCommentCharacter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.properties")
+	writeFile(t, path, "channel = #general # main channel\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	cp.Set("channel", "#random")
+	AssertEquals(t, nil, cp.Save(), "cp.Save error")
+
+	content, err := os.ReadFile(path)
+	AssertEquals(t, nil, err, "os.ReadFile error")
+	AssertEquals(t, "channel = #random # main channel\n", string(content), "saved content")
+}
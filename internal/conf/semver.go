@@ -0,0 +1,296 @@
+package conf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver is a parsed semantic version ('1.4.0-beta.1+build.5'). Build
+// metadata is kept but ignored when comparing versions
+type Semver struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// parseSemver parses versions following semver.org with an optional
+// leading 'v'
+func parseSemver(value string) (Semver, error) {
+	version := strings.TrimPrefix(strings.TrimSpace(value), "v")
+
+	var result Semver
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		result.Build = version[i+1:]
+		version = version[:i]
+		if err := validateSemverIdentifiers(result.Build, false); err != nil {
+			return Semver{}, fmt.Errorf("invalid build metadata '%s': %s", result.Build, err.Error())
+		}
+	}
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		result.Prerelease = version[i+1:]
+		version = version[:i]
+		if err := validateSemverIdentifiers(result.Prerelease, true); err != nil {
+			return Semver{}, fmt.Errorf("invalid prerelease '%s': %s", result.Prerelease, err.Error())
+		}
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return Semver{}, fmt.Errorf("expected 'major.minor.patch' but got '%s'", version)
+	}
+	targets := []*uint64{&result.Major, &result.Minor, &result.Patch}
+	for i, part := range parts {
+		n, err := parseSemverNumber(part)
+		if err != nil {
+			return Semver{}, err
+		}
+		*targets[i] = n
+	}
+
+	return result, nil
+}
+
+func parseSemverNumber(part string) (uint64, error) {
+	if len(part) > 1 && part[0] == '0' {
+		return 0, fmt.Errorf("number '%s' has a leading zero", part)
+	}
+	n, err := strconv.ParseUint(part, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a number", part)
+	}
+
+	return n, nil
+}
+
+// validateSemverIdentifiers checks the dot separated identifiers of a
+// prerelease or build metadata. Numeric prerelease identifiers must not
+// have leading zeros
+func validateSemverIdentifiers(identifiers string, prerelease bool) error {
+	for _, identifier := range strings.Split(identifiers, ".") {
+		if identifier == "" {
+			return fmt.Errorf("empty identifier")
+		}
+		for i := 0; i < len(identifier); i++ {
+			c := identifier[i]
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+				return fmt.Errorf("invalid character '%c' in identifier '%s'", c, identifier)
+			}
+		}
+		if prerelease && isSemverNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("identifier '%s' has a leading zero", identifier)
+		}
+	}
+
+	return nil
+}
+
+func isSemverNumeric(identifier string) bool {
+	for i := 0; i < len(identifier); i++ {
+		if identifier[i] < '0' || identifier[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or greater
+// than other. A prerelease is lower than the release of the same version
+func (v Semver) Compare(other Semver) int {
+	for _, pair := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			return compareUint(pair[0], pair[1])
+		}
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+
+	return comparePrerelease(strings.Split(v.Prerelease, "."), strings.Split(other.Prerelease, "."))
+}
+
+// comparePrerelease compares numeric identifiers numerically and others
+// lexically. Numeric identifiers are lower than alphanumeric ones and a
+// shorter list is lower if all of its identifiers are equal
+func comparePrerelease(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+
+		aNumeric, bNumeric := isSemverNumeric(a[i]), isSemverNumeric(b[i])
+		switch {
+		case aNumeric && bNumeric:
+			// Leading zeros are rejected so shorter means lower
+			if len(a[i]) != len(b[i]) {
+				return compareUint(uint64(len(a[i])), uint64(len(b[i])))
+			}
+			return strings.Compare(a[i], b[i])
+		case aNumeric:
+			return -1
+		case bNumeric:
+			return 1
+		default:
+			return strings.Compare(a[i], b[i])
+		}
+	}
+
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// LessThan reports whether v is lower than other
+func (v Semver) LessThan(other Semver) bool {
+	return v.Compare(other) < 0
+}
+
+// GreaterThan reports whether v is greater than other
+func (v Semver) GreaterThan(other Semver) bool {
+	return v.Compare(other) > 0
+}
+
+// Equal reports whether v and other have the same precedence
+func (v Semver) Equal(other Semver) bool {
+	return v.Compare(other) == 0
+}
+
+func (v Semver) String() string {
+	result := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		result += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		result += "+" + v.Build
+	}
+
+	return result
+}
+
+// SemverConstraint is a parsed version constraint like '>=1.4.0 <2.0.0'
+type SemverConstraint struct {
+	// Alternatives separated by '||' of which at least one must match,
+	// all comparators of an alternative must match
+	alternatives [][]semverComparator
+}
+
+type semverComparator struct {
+	operator string
+	version  Semver
+}
+
+// semverOperators is ordered so longer operators are matched first
+var semverOperators = []string{">=", "<=", "!=", ">", "<", "=", "^", "~"}
+
+// parseSemverConstraint parses whitespace separated comparators that
+// all have to match, alternatives are separated by '||'. Supported
+// operators are '=', '!=', '>', '>=', '<', '<=', '^' (same major
+// version, same minor version for 0.x and same patch for 0.0.x) and '~'
+// (same minor version) and may be followed by whitespace. Versions
+// without an operator have to match exactly
+func parseSemverConstraint(value string) (*SemverConstraint, error) {
+	constraint := &SemverConstraint{}
+	for _, alternative := range strings.Split(value, "||") {
+		fields := strings.Fields(alternative)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty constraint")
+		}
+
+		comparators := []semverComparator{}
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			operator := "="
+			for _, op := range semverOperators {
+				if strings.HasPrefix(field, op) {
+					operator = op
+					field = field[len(op):]
+					break
+				}
+			}
+			// The version may be separated from its operator by
+			// whitespace like in '>= 1.4.0'
+			if field == "" {
+				if i+1 == len(fields) {
+					return nil, fmt.Errorf("operator '%s' without version", operator)
+				}
+				i++
+				field = fields[i]
+			}
+
+			version, err := parseSemver(field)
+			if err != nil {
+				return nil, err
+			}
+			comparators = append(comparators, semverComparator{operator: operator, version: version})
+		}
+		constraint.alternatives = append(constraint.alternatives, comparators)
+	}
+
+	return constraint, nil
+}
+
+// Check reports whether v satisfies the constraint
+func (c *SemverConstraint) Check(v Semver) bool {
+	for _, comparators := range c.alternatives {
+		matches := true
+		for _, comparator := range comparators {
+			if !comparator.check(v) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c semverComparator) check(v Semver) bool {
+	cmp := v.Compare(c.version)
+	switch c.operator {
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "^":
+		// Like npm and Cargo the left-most non-zero number must match
+		switch {
+		case c.version.Major > 0:
+			return cmp >= 0 && v.Major == c.version.Major
+		case c.version.Minor > 0:
+			return cmp >= 0 && v.Major == 0 && v.Minor == c.version.Minor
+		default:
+			return cmp >= 0 && v.Major == 0 && v.Minor == 0 && v.Patch == c.version.Patch
+		}
+	case "~":
+		return cmp >= 0 && v.Major == c.version.Major && v.Minor == c.version.Minor
+	default:
+		return cmp == 0
+	}
+}
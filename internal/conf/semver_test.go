@@ -0,0 +1,184 @@
+package conf
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestGetSemver(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"plugin.min_version": "1.4.0",
+		"prefixed":           "v2.0.0-rc.1",
+		"build":              "1.0.0-alpha+001",
+	})
+
+	tests := []struct {
+		key      string
+		expected Semver
+	}{
+		{"plugin.min_version", Semver{Major: 1, Minor: 4}},
+		{"prefixed", Semver{Major: 2, Prerelease: "rc.1"}},
+		{"build", Semver{Major: 1, Prerelease: "alpha", Build: "001"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := cp.GetSemver(test.key)
+			AssertEquals(t, nil, err, "cp.GetSemver error")
+			AssertEquals(t, test.expected, value, "cp.GetSemver value")
+		})
+	}
+}
+
+func TestSemverOrdering(t *testing.T) {
+	// Ordered by precedence as in the example of semver.org
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.9.0",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for i := range versions {
+		for j := range versions {
+			a, err := parseSemver(versions[i])
+			AssertEquals(t, nil, err, "parseSemver error")
+			b, err := parseSemver(versions[j])
+			AssertEquals(t, nil, err, "parseSemver error")
+
+			expected := compareUint(uint64(i), uint64(j))
+			AssertEquals(t, expected, a.Compare(b), versions[i]+" compared to "+versions[j])
+		}
+	}
+}
+
+func TestSemverIgnoresBuildMetadata(t *testing.T) {
+	a, _ := parseSemver("1.0.0+20130313144700")
+	b, _ := parseSemver("1.0.0+exp.sha.5114f85")
+
+	AssertEquals(t, true, a.Equal(b), "a.Equal")
+	AssertEquals(t, false, a.LessThan(b), "a.LessThan")
+	AssertEquals(t, false, a.GreaterThan(b), "a.GreaterThan")
+	AssertEquals(t, "1.0.0+20130313144700", a.String(), "a.String")
+}
+
+func TestGetSemverInvalid(t *testing.T) {
+	tests := []struct {
+		value string
+		cause string
+	}{
+		{"1.4", "expected 'major.minor.patch' but got '1.4'"},
+		{"1.04.0", "number '04' has a leading zero"},
+		{"1.x.0", "'x' is not a number"},
+		{"1.0.0-", "invalid prerelease '': empty identifier"},
+		{"1.0.0-alpha..1", "invalid prerelease 'alpha..1': empty identifier"},
+		{"1.0.0-01", "invalid prerelease '01': identifier '01' has a leading zero"},
+		{"1.0.0+b_1", "invalid build metadata 'b_1': invalid character '_' in identifier 'b_1'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			cp := NewInMemoryConfigProvider(map[string]string{"version": test.value})
+			_, err := cp.GetSemver("version")
+			expected := newTypeConversionErrorWithCause("version", test.value, "semver", errors.New(test.cause))
+			AssertEquals(t, expected.Error(), err.Error(), "cp.GetSemver error")
+			AssertEquals(t, true, errors.Is(err, ErrTypeConversion), "errors.Is ErrTypeConversion")
+		})
+	}
+}
+
+func TestGetSemverConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{">=1.4.0 <2.0.0", "1.4.0", true},
+		{">=1.4.0 <2.0.0", "1.10.0", true},
+		{">=1.4.0 <2.0.0", "1.3.9", false},
+		{">=1.4.0 <2.0.0", "2.0.0", false},
+		{">=1.4.0 <2.0.0", "2.0.0-rc.1", true},
+		{">1.0.0", "1.0.0", false},
+		{"<=1.0.0", "1.0.0", true},
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{"!=1.2.3", "1.2.4", true},
+		{"^1.4.0", "1.9.9", true},
+		{"^1.4.0", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.2.2", false},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.2.3", "0.9.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0.3", "0.1.0", false},
+		{">= 1.4.0 < 2.0.0", "1.5.0", true},
+		{">= 1.4.0 < 2.0.0", "2.0.0", false},
+		{"^ 1.4.0", "1.9.0", true},
+		{"= 1.2.3", "1.2.3", true},
+		{"< 1.0.0 || >= 2.0.0", "2.1.0", true},
+		{"~1.4.0", "1.4.7", true},
+		{"~1.4.0", "1.5.0", false},
+		{"<1.0.0 || >=2.0.0", "0.9.0", true},
+		{"<1.0.0 || >=2.0.0", "1.5.0", false},
+		{"<1.0.0 || >=2.0.0", "2.1.0", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraint+" "+test.version, func(t *testing.T) {
+			cp := NewInMemoryConfigProvider(map[string]string{"plugin.versions": test.constraint})
+			constraint, err := cp.GetSemverConstraint("plugin.versions")
+			AssertEquals(t, nil, err, "cp.GetSemverConstraint error")
+
+			version, err := parseSemver(test.version)
+			AssertEquals(t, nil, err, "parseSemver error")
+			AssertEquals(t, test.expected, constraint.Check(version), "constraint.Check")
+		})
+	}
+}
+
+func TestGetSemverConstraintInvalid(t *testing.T) {
+	tests := []struct {
+		value string
+		cause string
+	}{
+		{"", "empty constraint"},
+		{">=1.4.0 ||", "empty constraint"},
+		{">=1.4", "expected 'major.minor.patch' but got '1.4'"},
+		{">=", "operator '>=' without version"},
+		{">=1.0.0 <", "operator '<' without version"},
+		{">= 1.4", "expected 'major.minor.patch' but got '1.4'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			cp := NewInMemoryConfigProvider(map[string]string{"constraint": test.value})
+			_, err := cp.GetSemverConstraint("constraint")
+			expected := newTypeConversionErrorWithCause("constraint", test.value, "semver", errors.New(test.cause))
+			AssertEquals(t, expected.Error(), err.Error(), "cp.GetSemverConstraint error")
+		})
+	}
+}
+
+func TestChainGetSemver(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{}),
+		NewPrefixConfigProvider("plugin.", NewInMemoryConfigProvider(map[string]string{"plugin.min_version": "1.4.0"})),
+	})
+
+	value, err := cp.GetSemver("min_version")
+	AssertEquals(t, nil, err, "cp.GetSemver error")
+	AssertEquals(t, "1.4.0", value.String(), "cp.GetSemver value")
+
+	_, err = cp.GetSemverConstraint("missing")
+	AssertEquals(t, true, errors.Is(err, ErrKeyNotFound), "errors.Is ErrKeyNotFound")
+}
@@ -0,0 +1,94 @@
+package conf
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSConfigError indicates that the TLS settings below Prefix are
+// incomplete or refer to unusable files
+type TLSConfigError struct {
+	Prefix  string
+	err     error
+	message string
+}
+
+func newTLSConfigError(prefix string, err error) *TLSConfigError {
+	return &TLSConfigError{
+		Prefix:  prefix,
+		err:     err,
+		message: fmt.Sprintf("invalid TLS config '%s': %v", prefix, err),
+	}
+}
+
+func (e *TLSConfigError) Error() string {
+	return e.message
+}
+
+func (e *TLSConfigError) Unwrap() error {
+	return e.err
+}
+
+// GetTLSConfig assembles a *tls.Config from the keys 'cert_file',
+// 'key_file', 'ca_file' and 'insecure_skip_verify' below the given
+// prefix (e.g. 'server.tls'). The files are resolved like in GetPath
+// and the CA file is used as pool of root CAs.
+// If none of the keys exists a KeyNotFoundError for the prefix is
+// returned so TLS can be treated as optional
+func GetTLSConfig(cp ConfigProvider, prefix string) (*tls.Config, error) {
+	paths := extended(cp)
+	certFile, certErr := paths.GetPath(joinKey(prefix, "cert_file"))
+	keyFile, keyErr := paths.GetPath(joinKey(prefix, "key_file"))
+	caFile, caErr := paths.GetPath(joinKey(prefix, "ca_file"))
+	for _, err := range []error{certErr, keyErr, caErr} {
+		if err != nil && !isKeyNotFound(err) {
+			return nil, err
+		}
+	}
+
+	skipKey := joinKey(prefix, "insecure_skip_verify")
+	insecureSkipVerify, skipErr := cp.GetBool(skipKey)
+	if skipErr != nil && !isKeyNotFound(skipErr) {
+		return nil, skipErr
+	}
+
+	if certErr != nil && keyErr != nil && caErr != nil && skipErr != nil {
+		return nil, NewKeyNotFoundError(prefix)
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	switch {
+	case certErr == nil && keyErr == nil:
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, newTLSConfigError(prefix, err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	case certErr == nil:
+		return nil, newTLSConfigError(prefix, errors.New("cert_file is set but key_file is missing"))
+	case keyErr == nil:
+		return nil, newTLSConfigError(prefix, errors.New("key_file is set but cert_file is missing"))
+	}
+
+	if caErr == nil {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, newTLSConfigError(prefix, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, newTLSConfigError(prefix, fmt.Errorf("ca_file '%s' contains no PEM certificates", caFile))
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
@@ -0,0 +1,133 @@
+package conf
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+// writeSelfSignedCert writes a self-signed certificate and its private
+// key to dir and returns their paths
+func writeSelfSignedCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	AssertEquals(t, nil, err, "ecdsa.GenerateKey error")
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "solvent.test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	AssertEquals(t, nil, err, "x509.CreateCertificate error")
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	AssertEquals(t, nil, err, "x509.MarshalECPrivateKey error")
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeFile(t, certFile, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	writeFile(t, keyFile, string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+
+	return certFile, keyFile
+}
+
+func TestGetTLSConfig(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t, t.TempDir())
+	cp := NewInMemoryConfigProvider(map[string]string{
+		"server.tls.cert_file":            certFile,
+		"server.tls.key_file":             keyFile,
+		"server.tls.ca_file":              certFile,
+		"server.tls.insecure_skip_verify": "true",
+	})
+
+	config, err := GetTLSConfig(cp, "server.tls")
+	AssertEquals(t, nil, err, "GetTLSConfig error")
+	AssertEquals(t, 1, len(config.Certificates), "len(config.Certificates)")
+	AssertEquals(t, true, config.InsecureSkipVerify, "config.InsecureSkipVerify")
+	AssertEquals(t, uint16(tls.VersionTLS12), config.MinVersion, "config.MinVersion")
+	AssertEquals(t, false, config.RootCAs == nil, "config.RootCAs set")
+}
+
+func TestGetTLSConfigRelativeToFile(t *testing.T) {
+	dir := t.TempDir()
+	AssertEquals(t, nil, os.Mkdir(filepath.Join(dir, "certs"), 0755), "os.Mkdir error")
+	writeSelfSignedCert(t, filepath.Join(dir, "certs"))
+	path := filepath.Join(dir, "app.properties")
+	writeFile(t, path, "tls.cert_file=certs/cert.pem\ntls.key_file=certs/key.pem\ntls.ca_file=certs/cert.pem\n")
+
+	// The working directory of the test is not dir
+	config, err := GetTLSConfig(NewFileConfigProvider(path), "tls")
+	AssertEquals(t, nil, err, "GetTLSConfig error")
+	AssertEquals(t, 1, len(config.Certificates), "len(config.Certificates)")
+	AssertEquals(t, false, config.RootCAs == nil, "config.RootCAs set")
+}
+
+func TestGetTLSConfigCAOnly(t *testing.T) {
+	certFile, _ := writeSelfSignedCert(t, t.TempDir())
+	cp := NewInMemoryConfigProvider(map[string]string{"tls.ca_file": certFile})
+
+	config, err := GetTLSConfig(cp, "tls")
+	AssertEquals(t, nil, err, "GetTLSConfig error")
+	AssertEquals(t, 0, len(config.Certificates), "len(config.Certificates)")
+	AssertEquals(t, false, config.InsecureSkipVerify, "config.InsecureSkipVerify")
+	AssertEquals(t, false, config.RootCAs == nil, "config.RootCAs set")
+}
+
+func TestGetTLSConfigAbsent(t *testing.T) {
+	cp := NewInMemoryConfigProvider(map[string]string{"server.port": "443"})
+
+	config, err := GetTLSConfig(cp, "server.tls")
+	AssertEquals(t, NewKeyNotFoundError("server.tls"), err, "GetTLSConfig error")
+	AssertEquals(t, (*tls.Config)(nil), config, "GetTLSConfig config")
+}
+
+func TestGetTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir)
+	garbage := filepath.Join(dir, "garbage.pem")
+	writeFile(t, garbage, "not a certificate")
+
+	tests := []struct {
+		name   string
+		values map[string]string
+	}{
+		{"cert without key", map[string]string{"tls.cert_file": certFile}},
+		{"key without cert", map[string]string{"tls.key_file": keyFile}},
+		{"swapped files", map[string]string{"tls.cert_file": keyFile, "tls.key_file": certFile}},
+		{"missing ca", map[string]string{"tls.ca_file": filepath.Join(dir, "missing.pem")}},
+		{"invalid ca", map[string]string{"tls.ca_file": garbage}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GetTLSConfig(NewInMemoryConfigProvider(test.values), "tls")
+			var tlsErr *TLSConfigError
+			AssertEquals(t, true, errors.As(err, &tlsErr), "errors.As TLSConfigError")
+			AssertEquals(t, "tls", tlsErr.Prefix, "tlsErr.Prefix")
+		})
+	}
+
+	_, err := GetTLSConfig(NewInMemoryConfigProvider(map[string]string{"tls.ca_file": filepath.Join(dir, "missing.pem")}), "tls")
+	AssertEquals(t, true, errors.Is(err, os.ErrNotExist), "errors.Is os.ErrNotExist")
+
+	_, err = GetTLSConfig(NewInMemoryConfigProvider(map[string]string{"tls.insecure_skip_verify": "maybe"}), "tls")
+	AssertEquals(t, NewTypeConversionError("tls.insecure_skip_verify", "maybe", "bool"), err, "GetTLSConfig error")
+}
@@ -0,0 +1,99 @@
+package conf
+
+import (
+	"errors"
+	"io"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TOMLFileConfigProvider reads config values from a TOML document where
+// tables are flattened into dot-delimited keys (e.g. 'host' in the
+// '[database]' table is available as 'database.host')
+type TOMLFileConfigProvider struct {
+	typedGetters
+	doc *document
+}
+
+// NewTOMLFileConfigProvider creates a new TOMLFileConfigProvider for the
+// TOML file at the given path and returns the error of reading or
+// parsing it. The file is read again on the next access after its
+// modification time changed. Paths are resolved like in
+// NewFileConfigProvider
+func NewTOMLFileConfigProvider(path string, opts ...Option) (*TOMLFileConfigProvider, error) {
+	cp := &TOMLFileConfigProvider{}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+	cp.doc = newFileDocument(callerRelativePath(path), cp.options.reloadCheckInterval, cp.parse)
+	if _, err := cp.doc.loadStore(); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// NewTOMLConfigProviderFromReader creates a new TOMLFileConfigProvider
+// that lazily reads its TOML document from the given reader
+func NewTOMLConfigProviderFromReader(r io.Reader, opts ...Option) *TOMLFileConfigProvider {
+	cp := &TOMLFileConfigProvider{}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+	cp.doc = newReaderDocument(r, cp.parse)
+
+	return cp
+}
+
+func (cp *TOMLFileConfigProvider) GetString(key string) (string, error) {
+	return cp.doc.getString(key, cp.options)
+}
+
+// Keys returns the sorted flattened keys or nil if the TOML document
+// cannot be read
+func (cp *TOMLFileConfigProvider) Keys() []string {
+	return cp.doc.keys()
+}
+
+// All returns a copy of all flattened values
+func (cp *TOMLFileConfigProvider) All() (map[string]string, error) {
+	return cp.doc.all()
+}
+
+func (cp *TOMLFileConfigProvider) parse(r io.Reader) (map[string]string, error) {
+	store, err := initMapFromTOML(r, cp.options.separator)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeKeys(store, cp.options)
+}
+
+// initMapFromTOML flattens the TOML document read from r and joins
+// arrays of scalars with the given separator
+func initMapFromTOML(r io.Reader, separator string) (map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &UnknownError{
+			err:     err,
+			message: "could not read TOML config",
+		}
+	}
+
+	var object map[string]interface{}
+	if _, err := toml.Decode(string(data), &object); err != nil {
+		return nil, tomlParsingError(string(data), err)
+	}
+
+	store := map[string]string{}
+	flattenValue(store, "", object, separator)
+
+	return store, nil
+}
+
+// tomlParsingError returns a ParsingError for the line of the TOML
+// document the given decoding error points to
+func tomlParsingError(data string, err error) *ParsingError {
+	var parseErr toml.ParseError
+	if !errors.As(err, &parseErr) {
+		return NewParsingError(err.Error())
+	}
+
+	return parsingErrorAt(data, parseErr.Position.Line, err)
+}
@@ -0,0 +1,112 @@
+package conf
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func newTOMLFileProvider(t *testing.T, path string, opts ...Option) *TOMLFileConfigProvider {
+	t.Helper()
+
+	cp, err := NewTOMLFileConfigProvider(path, opts...)
+	AssertEquals(t, nil, err, "NewTOMLFileConfigProvider error")
+
+	return cp
+}
+
+func TestTOMLGetString(t *testing.T) {
+	cp := newTOMLFileProvider(t, "testdata/test.toml")
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"title", "solvent"},
+		{"debug", "true"},
+		{"database.host", "localhost"},
+		{"database.port", "5432"},
+		{"database.created", "2021-03-04T05:06:07Z"},
+		{"server.origins", "https://a.com,https://b.com"},
+		{"server.replicas.0.name", "a"},
+		{"server.replicas.1.name", "b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := cp.GetString(test.key)
+			AssertEquals(t, nil, err, "cp.GetString error")
+			AssertEquals(t, test.expected, value, "cp.GetString value")
+		})
+	}
+
+	_, err := cp.GetString("database")
+	AssertEquals(t, NewKeyNotFoundError("database"), err, "cp.GetString error")
+}
+
+func TestTOMLTypedGetters(t *testing.T) {
+	cp := newTOMLFileProvider(t, "testdata/test.toml")
+
+	debug, err := cp.GetBool("debug")
+	AssertEquals(t, nil, err, "cp.GetBool error")
+	AssertEquals(t, true, debug, "cp.GetBool value")
+
+	ratio, err := cp.GetFloat("database.ratio")
+	AssertEquals(t, nil, err, "cp.GetFloat error")
+	AssertEquals(t, 0.75, ratio, "cp.GetFloat value")
+
+	timeout, err := cp.GetDuration("database.timeout")
+	AssertEquals(t, nil, err, "cp.GetDuration error")
+	AssertEquals(t, 5*time.Second, timeout, "cp.GetDuration value")
+
+	created, err := cp.GetTime("database.created")
+	AssertEquals(t, nil, err, "cp.GetTime error")
+	AssertEquals(t, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), created.UTC(), "cp.GetTime value")
+
+	ports, err := cp.GetIntSlice("server.ports")
+	AssertEquals(t, nil, err, "cp.GetIntSlice error")
+	AssertEquals(t, []int{8080, 8081}, ports, "cp.GetIntSlice value")
+
+	origins, err := cp.GetStringSlice("server.origins")
+	AssertEquals(t, nil, err, "cp.GetStringSlice error")
+	AssertEquals(t, []string{"https://a.com", "https://b.com"}, origins, "cp.GetStringSlice value")
+}
+
+func TestTOMLMalformed(t *testing.T) {
+	cp := NewTOMLConfigProviderFromReader(strings.NewReader("[database]\nhost = localhost\n"))
+
+	_, err := cp.GetString("database.host")
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+	AssertEquals(t, 2, parsingErr.LineNumber, "parsingErr.LineNumber")
+	AssertEquals(t, "host = localhost", parsingErr.Line, "parsingErr.Line")
+
+	cause := errors.Unwrap(err)
+	AssertEquals(t, true, cause != nil, "parsingErr has a cause")
+	AssertEquals(t, NewParsingErrorAt(2, "host = localhost").Error()+": "+cause.Error(), err.Error(), "cp.GetString error")
+	AssertEquals(t, []string(nil), cp.Keys(), "cp.Keys")
+}
+
+func TestTOMLKeys(t *testing.T) {
+	cp := NewTOMLConfigProviderFromReader(strings.NewReader("b = 1\n[a]\nc = 2\n"))
+
+	AssertEquals(t, []string{"a.c", "b"}, cp.Keys(), "cp.Keys")
+}
+
+func TestTOMLFileErrors(t *testing.T) {
+	cp, err := NewTOMLFileConfigProvider("testdata/missing.toml")
+	var unknownErr *UnknownError
+	AssertEquals(t, true, errors.As(err, &unknownErr), "errors.As UnknownError")
+	AssertEquals(t, (*TOMLFileConfigProvider)(nil), cp, "cp")
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	writeFile(t, path, "[database]\nhost = localhost\n")
+	_, err = NewTOMLFileConfigProvider(path)
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+	AssertEquals(t, 2, parsingErr.LineNumber, "parsingErr.LineNumber")
+}
@@ -0,0 +1,214 @@
+package conf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kind is the expected type of a value checked by ValidateKinds
+type Kind int
+
+const (
+	KindString Kind = iota + 1
+	KindBool
+	KindInt
+	KindInt64
+	KindUint
+	KindFloat
+	KindDuration
+	KindTime
+	KindStringSlice
+	KindPort
+)
+
+// ValidationError lists all required keys that are missing and all
+// values that could not be converted to their expected kind
+type ValidationError struct {
+	Missing []string
+	Errors  []error
+	message string
+}
+
+func NewValidationError(missing []string, errs []error) *ValidationError {
+	messages := []string{}
+	if len(missing) > 0 {
+		messages = append(messages, fmt.Sprintf("missing required keys '%s'", strings.Join(missing, "', '")))
+	}
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return &ValidationError{
+		Missing: missing,
+		Errors:  errs,
+		message: fmt.Sprintf("config is invalid: %s", strings.Join(messages, "; ")),
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return e.message
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// Is matches ErrKeyNotFound if required keys are missing since those are
+// not part of the unwrapped Errors
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrKeyNotFound && len(e.Missing) > 0
+}
+
+// kindGetters are the getters needed to check the kinds of ValidateKinds
+type kindGetters interface {
+	GetString(key string) (string, error)
+	GetBool(key string) (bool, error)
+	GetInt(key string) (int, error)
+	GetInt64(key string) (int64, error)
+	GetUint(key string) (uint, error)
+	GetFloat(key string) (float64, error)
+	GetDuration(key string) (time.Duration, error)
+	GetTime(key string) (time.Time, error)
+	GetStringSlice(key string) ([]string, error)
+	GetPort(key string) (int, error)
+}
+
+// Validate checks that all required keys are present and returns a
+// ValidationError listing every missing key otherwise
+func (g typedGetters) Validate(required []string) error {
+	return validateKinds(stringGetter{g}, kindsOf(required))
+}
+
+// ValidateKinds is like Validate but also checks that every value can be
+// converted to the given kind. Conversion errors are collected in the
+// Errors of the ValidationError
+func (g typedGetters) ValidateKinds(spec map[string]Kind) error {
+	return validateKinds(stringGetter{g}, spec)
+}
+
+// stringGetter completes typedGetters with the GetString method of the
+// provider they belong to
+type stringGetter struct {
+	typedGetters
+}
+
+func (g stringGetter) GetString(key string) (string, error) {
+	return g.getString(key)
+}
+
+// Validate checks that every required key is present in at least one
+// provider of the chain
+func (cp *ChainConfigProvider) Validate(required []string) error {
+	return validateKinds(cp, kindsOf(required))
+}
+
+// ValidateKinds is like Validate but also checks the kinds of the values
+// the chain returns
+func (cp *ChainConfigProvider) ValidateKinds(spec map[string]Kind) error {
+	return validateKinds(cp, spec)
+}
+
+// kindNames maps the type names accepted by the package level Validate
+// to their kind
+var kindNames = map[string]Kind{
+	"string":        KindString,
+	"bool":          KindBool,
+	"int":           KindInt,
+	"int64":         KindInt64,
+	"uint":          KindUint,
+	"float64":       KindFloat,
+	"time.Duration": KindDuration,
+	"time.Time":     KindTime,
+	"[]string":      KindStringSlice,
+}
+
+// Validate checks every key of required (mapping keys to type names like
+// "string", "float64", "bool" or "int") and returns one error per
+// missing or unconvertible key ordered by key, so all problems of a
+// config can be reported at startup at once. Unknown type names result
+// in an UnsupportedTypeError
+func Validate(provider ConfigProvider, required map[string]string) []error {
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	errs := []error{}
+	for _, key := range keys {
+		kind, ok := kindNames[required[key]]
+		if !ok {
+			errs = append(errs, NewUnsupportedTypeError(required[key]))
+			continue
+		}
+		if err := checkKind(provider, key, kind); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func kindsOf(required []string) map[string]Kind {
+	spec := make(map[string]Kind, len(required))
+	for _, key := range required {
+		spec[key] = KindString
+	}
+
+	return spec
+}
+
+func validateKinds(g kindGetters, spec map[string]Kind) error {
+	keys := make([]string, 0, len(spec))
+	for key := range spec {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	missing := []string{}
+	errs := []error{}
+	for _, key := range keys {
+		err := checkKind(g, key, spec[key])
+		if isKeyNotFound(err) {
+			missing = append(missing, key)
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(missing) > 0 || len(errs) > 0 {
+		return NewValidationError(missing, errs)
+	}
+
+	return nil
+}
+
+func checkKind(g kindGetters, key string, kind Kind) error {
+	var err error
+	switch kind {
+	case KindBool:
+		_, err = g.GetBool(key)
+	case KindInt:
+		_, err = g.GetInt(key)
+	case KindInt64:
+		_, err = g.GetInt64(key)
+	case KindUint:
+		_, err = g.GetUint(key)
+	case KindFloat:
+		_, err = g.GetFloat(key)
+	case KindDuration:
+		_, err = g.GetDuration(key)
+	case KindTime:
+		_, err = g.GetTime(key)
+	case KindStringSlice:
+		_, err = g.GetStringSlice(key)
+	case KindPort:
+		_, err = g.GetPort(key)
+	default:
+		_, err = g.GetString(key)
+	}
+
+	return err
+}
@@ -0,0 +1,68 @@
+package conf
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestValidate(t *testing.T) {
+	cp := NewFileConfigProvider(testFile)
+
+	AssertEquals(t, nil, cp.Validate([]string{"string", "int"}), "cp.Validate error")
+
+	err := cp.Validate([]string{"string", "db.host", "db.port"})
+	AssertEquals(t, NewValidationError([]string{"db.host", "db.port"}, []error{}), err, "cp.Validate error")
+	AssertEquals(t, "config is invalid: missing required keys 'db.host', 'db.port'", err.Error(), "err.Error")
+}
+
+func TestValidateKinds(t *testing.T) {
+	cp := NewChainConfigProvider([]ConfigProvider{
+		NewInMemoryConfigProvider(map[string]string{"port": "http", "timeout": "soon"}),
+		NewFileConfigProvider(testFile),
+	})
+
+	AssertEquals(t, nil, cp.ValidateKinds(map[string]Kind{"int": KindInt, "float": KindFloat}), "cp.ValidateKinds error")
+
+	err := cp.ValidateKinds(map[string]Kind{
+		"port":    KindPort,
+		"timeout": KindDuration,
+		"bool":    KindBool,
+		"db.host": KindString,
+		"db.name": KindString,
+	})
+	validationErr, ok := err.(*ValidationError)
+	AssertEquals(t, true, ok, "err is ValidationError")
+	AssertEquals(t, []string{"db.host", "db.name"}, validationErr.Missing, "validationErr.Missing")
+	AssertEquals(t, 2, len(validationErr.Errors), "len(validationErr.Errors)")
+
+	var conversionErr *TypeConversionError
+	for i, key := range []string{"port", "timeout"} {
+		AssertEquals(t, true, errors.As(validationErr.Errors[i], &conversionErr), "errors.As TypeConversionError")
+		AssertEquals(t, key, conversionErr.Key, "conversionErr.Key")
+	}
+}
+
+func TestValidateProvider(t *testing.T) {
+	errs := Validate(NewFileConfigProvider(testFile), map[string]string{"string": "string", "int": "int", "float": "float64", "bool": "bool"})
+	AssertEquals(t, []error{}, errs, "Validate errors")
+
+	cp := NewInMemoryConfigProvider(map[string]string{"string": "value", "port": "http", "ratio": "half"})
+
+	errs = Validate(cp, map[string]string{
+		"string":  "string",
+		"port":    "int",
+		"ratio":   "float64",
+		"db.host": "string",
+		"db.tls":  "bool",
+		"size":    "complex128",
+	})
+	AssertEquals(t, []error{
+		NewKeyNotFoundError("db.host"),
+		NewKeyNotFoundError("db.tls"),
+		NewTypeConversionError("port", "http", "int"),
+		NewTypeConversionError("ratio", "half", "float64"),
+		NewUnsupportedTypeError("complex128"),
+	}, errs, "Validate errors")
+}
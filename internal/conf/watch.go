@@ -0,0 +1,139 @@
+package conf
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const watchDebounce = 100 * time.Millisecond
+
+// OnReload registers a callback that is invoked with copies of the old
+// and the new values after every successful reload
+func (cp *FileConfigProvider) OnReload(f func(old, new map[string]string)) {
+	cp.callbackMutex.Lock()
+	defer cp.callbackMutex.Unlock()
+
+	cp.reloadCallbacks = append(cp.reloadCallbacks, f)
+}
+
+// OnReloadError registers a callback that is invoked whenever a reload
+// triggered by Watch fails
+func (cp *FileConfigProvider) OnReloadError(f func(err error)) {
+	cp.callbackMutex.Lock()
+	defer cp.callbackMutex.Unlock()
+
+	cp.reloadErrorCallbacks = append(cp.reloadErrorCallbacks, f)
+}
+
+// Watch reloads the file whenever it changes until the given context is
+// cancelled. The directory of the file is watched instead of the file
+// itself so editors that save by renaming a temporary file over the
+// original are supported as well. Bursts of changes are debounced
+func (cp *FileConfigProvider) Watch(ctx context.Context) error {
+	watcher, err := cp.newWatcher()
+	if err != nil {
+		return err
+	}
+
+	go cp.watchLoop(ctx, watcher)
+
+	return nil
+}
+
+// WatchAndReload is like Watch but blocks until the given context is
+// cancelled, which makes it easy to run next to other long-running
+// tasks of a service
+func (cp *FileConfigProvider) WatchAndReload(ctx context.Context) error {
+	watcher, err := cp.newWatcher()
+	if err != nil {
+		return err
+	}
+
+	cp.watchLoop(ctx, watcher)
+
+	return nil
+}
+
+func (cp *FileConfigProvider) newWatcher() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, &UnknownError{
+			err:     err,
+			message: "could not create file watcher",
+		}
+	}
+
+	if err := watcher.Add(filepath.Dir(cp.path)); err != nil {
+		watcher.Close()
+		return nil, &UnknownError{
+			err:     err,
+			message: fmt.Sprintf("could not watch file with path '%s'", cp.path),
+		}
+	}
+
+	return watcher, nil
+}
+
+func (cp *FileConfigProvider) watchLoop(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) == cp.path && event.Has(fsnotify.Write|fsnotify.Create) {
+				debounce.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			cp.reloadFailed(err)
+		case <-debounce.C:
+			if err := cp.Reload(); err != nil {
+				cp.reloadFailed(err)
+			}
+		}
+	}
+}
+
+func (cp *FileConfigProvider) reloadFailed(err error) {
+	cp.callbackMutex.Lock()
+	callbacks := cp.reloadErrorCallbacks
+	cp.callbackMutex.Unlock()
+
+	for _, f := range callbacks {
+		f(err)
+	}
+}
+
+func (cp *FileConfigProvider) reloaded(old, new map[string]string) {
+	cp.callbackMutex.Lock()
+	callbacks := cp.reloadCallbacks
+	cp.callbackMutex.Unlock()
+
+	for _, f := range callbacks {
+		f(copyMap(old), copyMap(new))
+	}
+}
+
+func copyMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for key, value := range m {
+		c[key] = value
+	}
+
+	return c
+}
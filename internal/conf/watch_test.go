@@ -0,0 +1,128 @@
+package conf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watch.properties")
+	writeFile(t, path, "port=8080\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	reloads := make(chan map[string]string, 10)
+	cp.OnReload(func(old, new map[string]string) {
+		reloads <- new
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	AssertEquals(t, nil, cp.Watch(ctx), "cp.Watch error")
+
+	writeFile(t, path, "port=9090\n")
+	AssertEquals(t, map[string]string{"port": "9090"}, waitForReload(t, reloads), "reloaded values")
+
+	tmpPath := filepath.Join(dir, "watch.properties.tmp")
+	writeFile(t, tmpPath, "port=7070\n")
+	AssertEquals(t, nil, os.Rename(tmpPath, path), "os.Rename error")
+	AssertEquals(t, map[string]string{"port": "7070"}, waitForReload(t, reloads), "reloaded values")
+
+	value, err := cp.GetInt("port")
+	AssertEquals(t, nil, err, "cp.GetInt error")
+	AssertEquals(t, 7070, value, "cp.GetInt value")
+}
+
+func TestWatchDebounce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch.properties")
+	writeFile(t, path, "count=0\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	reloads := make(chan map[string]string, 10)
+	cp.OnReload(func(old, new map[string]string) {
+		reloads <- new
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	AssertEquals(t, nil, cp.Watch(ctx), "cp.Watch error")
+
+	writeFile(t, path, "count=1\n")
+	writeFile(t, path, "count=2\n")
+	writeFile(t, path, "count=3\n")
+	AssertEquals(t, map[string]string{"count": "3"}, waitForReload(t, reloads), "reloaded values")
+
+	select {
+	case values := <-reloads:
+		t.Errorf("unexpected additional reload with values %v", values)
+	case <-time.After(3 * watchDebounce):
+	}
+}
+
+func TestWatchReloadError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch.properties")
+	writeFile(t, path, "port=8080\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	errs := make(chan error, 10)
+	cp.OnReloadError(func(err error) {
+		errs <- err
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	AssertEquals(t, nil, cp.Watch(ctx), "cp.Watch error")
+
+	writeFile(t, path, "invalid\n")
+	select {
+	case err := <-errs:
+		AssertEquals(t, NewParsingErrorAt(1, "invalid"), err, "reload error")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload error")
+	}
+}
+
+func waitForReload(t *testing.T, reloads chan map[string]string) map[string]string {
+	select {
+	case values := <-reloads:
+		return values
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+		return nil
+	}
+}
+
+func TestWatchAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch.properties")
+	writeFile(t, path, "port=8080\n")
+	cp := newFileConfigProvider(path, false, nil)
+
+	reloads := make(chan map[string]string, 10)
+	cp.OnReload(func(old, new map[string]string) {
+		reloads <- new
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- cp.WatchAndReload(ctx)
+	}()
+
+	// Give the watcher time to be registered before the file changes
+	time.Sleep(50 * time.Millisecond)
+	writeFile(t, path, "port=9090\n")
+	AssertEquals(t, map[string]string{"port": "9090"}, waitForReload(t, reloads), "reloaded values")
+
+	cancel()
+	select {
+	case err := <-done:
+		AssertEquals(t, nil, err, "cp.WatchAndReload error")
+	case <-time.After(time.Second):
+		t.Fatal("cp.WatchAndReload did not return after cancel")
+	}
+}
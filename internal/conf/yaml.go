@@ -0,0 +1,178 @@
+package conf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var yamlErrorLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// YAMLFileConfigProvider reads config values from a YAML document where
+// nested mappings are flattened into dot-delimited keys (e.g. 'host'
+// below 'database' is available as 'database.host')
+type YAMLFileConfigProvider struct {
+	typedGetters
+	doc *document
+}
+
+// NewYAMLFileConfigProvider creates a new YAMLFileConfigProvider for the
+// YAML file at the given path and returns the error of reading or
+// parsing it. The file is read again on the next access after its
+// modification time changed. Paths are resolved like in
+// NewFileConfigProvider
+func NewYAMLFileConfigProvider(path string, opts ...Option) (*YAMLFileConfigProvider, error) {
+	cp := &YAMLFileConfigProvider{}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+	cp.doc = newFileDocument(callerRelativePath(path), cp.options.reloadCheckInterval, cp.parse)
+	if _, err := cp.doc.loadStore(); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// NewYAMLConfigProviderFromReader creates a new YAMLFileConfigProvider
+// that lazily reads its YAML document from the given reader
+func NewYAMLConfigProviderFromReader(r io.Reader, opts ...Option) *YAMLFileConfigProvider {
+	cp := &YAMLFileConfigProvider{}
+	cp.typedGetters = newTypedGetters(cp.GetString, opts)
+	cp.doc = newReaderDocument(r, cp.parse)
+
+	return cp
+}
+
+func (cp *YAMLFileConfigProvider) GetString(key string) (string, error) {
+	return cp.doc.getString(key, cp.options)
+}
+
+// Keys returns the sorted flattened keys or nil if the YAML document
+// cannot be read
+func (cp *YAMLFileConfigProvider) Keys() []string {
+	return cp.doc.keys()
+}
+
+// All returns a copy of all flattened values
+func (cp *YAMLFileConfigProvider) All() (map[string]string, error) {
+	return cp.doc.all()
+}
+
+func (cp *YAMLFileConfigProvider) parse(r io.Reader) (map[string]string, error) {
+	store, err := initMapFromYAML(r, cp.options.separator)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeKeys(store, cp.options)
+}
+
+// initMapFromYAML flattens all documents of the YAML stream read from r
+// into one store where later documents override earlier ones. Sequences
+// of scalars are joined with the given separator
+func initMapFromYAML(r io.Reader, separator string) (map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &UnknownError{
+			err:     err,
+			message: "could not read YAML config",
+		}
+	}
+
+	store := map[string]string{}
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, yamlParsingError(string(data), err)
+		}
+
+		flattenYAML(store, "", &node, separator)
+	}
+
+	return store, nil
+}
+
+func flattenYAML(store map[string]string, key string, node *yaml.Node, separator string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			flattenYAML(store, key, child, separator)
+		}
+	case yaml.AliasNode:
+		flattenYAML(store, key, node.Alias, separator)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childKey, child := node.Content[i], node.Content[i+1]
+			if childKey.Value == "<<" && childKey.Tag == "!!merge" {
+				flattenYAML(store, key, child, separator)
+				continue
+			}
+			flattenYAML(store, joinKey(key, childKey.Value), child, separator)
+		}
+	case yaml.SequenceNode:
+		elements := make([]string, 0, len(node.Content))
+		for _, child := range node.Content {
+			if child.Kind == yaml.ScalarNode {
+				elements = append(elements, yamlScalar(child))
+			}
+		}
+		if len(elements) == len(node.Content) {
+			store[key] = strings.Join(elements, separator)
+			return
+		}
+		// Mixed sequences are indexed element by element so their
+		// scalars are kept as well
+		for i, child := range node.Content {
+			flattenYAML(store, joinKey(key, fmt.Sprint(i)), child, separator)
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			// null values are treated as absent
+			return
+		}
+		store[key] = yamlScalar(node)
+	}
+}
+
+// yamlScalar normalizes booleans including the YAML 1.1 literals 'yes',
+// 'no', 'on' and 'off' to 'true' and 'false' so GetBool works for them
+func yamlScalar(node *yaml.Node) string {
+	if node.Tag == "!!bool" {
+		var value bool
+		if err := node.Decode(&value); err == nil {
+			return strconv.FormatBool(value)
+		}
+	}
+
+	if node.Tag == "!!str" && node.Style == 0 {
+		switch strings.ToLower(node.Value) {
+		case "yes", "on":
+			return "true"
+		case "no", "off":
+			return "false"
+		}
+	}
+
+	return node.Value
+}
+
+// yamlParsingError returns a ParsingError for the line of the YAML
+// document the given decoding error points to
+func yamlParsingError(data string, err error) *ParsingError {
+	match := yamlErrorLinePattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return NewParsingError(err.Error())
+	}
+
+	lineNumber, _ := strconv.Atoi(match[1])
+	return parsingErrorAt(data, lineNumber, err)
+}
@@ -0,0 +1,142 @@
+package conf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/eldelto/solvent/internal/testutils"
+)
+
+func newYAMLFileProvider(t *testing.T, path string, opts ...Option) *YAMLFileConfigProvider {
+	t.Helper()
+
+	cp, err := NewYAMLFileConfigProvider(path, opts...)
+	AssertEquals(t, nil, err, "NewYAMLFileConfigProvider error")
+
+	return cp
+}
+
+func TestYAMLGetString(t *testing.T) {
+	cp := newYAMLFileProvider(t, "testdata/test.yaml")
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"title", "solvent-override"},
+		{"extra", "1"},
+		{"features.enabled", "true"},
+		{"features.beta", "false"},
+		{"features.quoted", "yes"},
+		{"features.strict", "true"},
+		{"database.host", "localhost"},
+		{"replica.host", "replica"},
+		{"replica.port", "5432"},
+		{"server.origins", "https://a.com,https://b.com"},
+		{"server.replicas.0.name", "a"},
+		{"server.replicas.1.name", "b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := cp.GetString(test.key)
+			AssertEquals(t, nil, err, "cp.GetString error")
+			AssertEquals(t, test.expected, value, "cp.GetString value")
+		})
+	}
+
+	_, err := cp.GetString("database.password")
+	AssertEquals(t, NewKeyNotFoundError("database.password"), err, "cp.GetString error")
+}
+
+func TestYAMLTypedGetters(t *testing.T) {
+	cp := newYAMLFileProvider(t, "testdata/test.yaml")
+
+	enabled, err := cp.GetBool("features.enabled")
+	AssertEquals(t, nil, err, "cp.GetBool error")
+	AssertEquals(t, true, enabled, "cp.GetBool value")
+
+	port, err := cp.GetPort("database.port")
+	AssertEquals(t, nil, err, "cp.GetPort error")
+	AssertEquals(t, 5432, port, "cp.GetPort value")
+
+	timeout, err := cp.GetDuration("database.timeout")
+	AssertEquals(t, nil, err, "cp.GetDuration error")
+	AssertEquals(t, 5*time.Second, timeout, "cp.GetDuration value")
+
+	origins, err := cp.GetStringSlice("server.origins")
+	AssertEquals(t, nil, err, "cp.GetStringSlice error")
+	AssertEquals(t, []string{"https://a.com", "https://b.com"}, origins, "cp.GetStringSlice value")
+}
+
+func TestYAMLMalformed(t *testing.T) {
+	cp := NewYAMLConfigProviderFromReader(strings.NewReader("database:\n  host: localhost\n port: 5432\n"))
+
+	_, err := cp.GetString("database.host")
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+	AssertEquals(t, 2, parsingErr.LineNumber, "parsingErr.LineNumber")
+	AssertEquals(t, []string(nil), cp.Keys(), "cp.Keys")
+}
+
+func TestYAMLMixedSequence(t *testing.T) {
+	cp := NewYAMLConfigProviderFromReader(strings.NewReader("items:\n  - 1\n  - a: 2\n  - three\n"))
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"items.0", "1"},
+		{"items.1.a", "2"},
+		{"items.2", "three"},
+	}
+
+	for _, test := range tests {
+		value, err := cp.GetString(test.key)
+		AssertEquals(t, nil, err, "cp.GetString error")
+		AssertEquals(t, test.expected, value, "cp.GetString value")
+	}
+
+	AssertEquals(t, []string{"items.0", "items.1.a", "items.2"}, cp.Keys(), "cp.Keys")
+}
+
+func TestYAMLKeys(t *testing.T) {
+	cp := NewYAMLConfigProviderFromReader(strings.NewReader("b: 1\na:\n  c: 2\n---\nd: 3\n"))
+
+	AssertEquals(t, []string{"a.c", "b", "d"}, cp.Keys(), "cp.Keys")
+}
+
+func TestYAMLFileErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	cp, err := NewYAMLFileConfigProvider(path)
+	var unknownErr *UnknownError
+	AssertEquals(t, true, errors.As(err, &unknownErr), "errors.As UnknownError")
+	AssertEquals(t, (*YAMLFileConfigProvider)(nil), cp, "cp")
+
+	writeFile(t, path, "database:\n  host: localhost\n port: 5432\n")
+	_, err = NewYAMLFileConfigProvider(path)
+	var parsingErr *ParsingError
+	AssertEquals(t, true, errors.As(err, &parsingErr), "errors.As ParsingError")
+}
+
+func TestYAMLReloadOnModification(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "database:\n  host: localhost\n")
+	cp := newYAMLFileProvider(t, path, WithReloadCheckInterval(0))
+
+	value, err := cp.GetString("database.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "localhost", value, "cp.GetString value")
+
+	writeFile(t, path, "database:\n  host: replica\n")
+	modTime := time.Now().Add(time.Minute)
+	AssertEquals(t, nil, os.Chtimes(path, modTime, modTime), "os.Chtimes error")
+
+	value, err = cp.GetString("database.host")
+	AssertEquals(t, nil, err, "cp.GetString error")
+	AssertEquals(t, "replica", value, "cp.GetString value")
+}
@@ -20,18 +20,14 @@ type Controller interface {
 	RegisterRoutes(router *mux.Router)
 }
 
-var simCp = conf.NewFileConfigProvider("conf/sim.properties")
-var prodCp = conf.NewFileConfigProvider("conf/prod.properties")
-var secretsCp = conf.NewFileConfigProvider("secrets/prod.properties")
-var config = conf.NewChainConfigProvider([]conf.ConfigProvider{simCp, prodCp, secretsCp})
+var envCp = conf.NewEnvConfigProvider("SOLVENT_")
+var simCp = conf.NewOptionalFileConfigProvider("conf/sim.properties")
+var prodCp = conf.NewOptionalFileConfigProvider("conf/prod.properties")
+var secretsCp = conf.NewOptionalFileConfigProvider("secrets/prod.properties")
+var config = conf.NewChainConfigProvider([]conf.ConfigProvider{envCp, simCp, prodCp, secretsCp})
 
 //var repository = persistence.NewInMemoryRepository()
-var repository, postgresRepositoryErr = persistence.NewPostgresRepository(
-	config.GetString("postgres.host"),
-	config.GetString("postgres.port"),
-	config.GetString("postgres.user"),
-	config.GetString("postgres.password"),
-)
+var repository, postgresRepositoryErr = newPostgresRepository(config)
 
 var service = serv.NewService(repository)
 var mainController = controller.NewMainController(&service)
@@ -66,6 +62,27 @@ func main() {
 	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", port), nil))
 }
 
+func newPostgresRepository(config conf.ConfigProvider) (*persistence.PostgresRepository, error) {
+	host, err := config.GetString("postgres.host")
+	if err != nil {
+		return nil, err
+	}
+	port, err := config.GetString("postgres.port")
+	if err != nil {
+		return nil, err
+	}
+	user, err := config.GetString("postgres.user")
+	if err != nil {
+		return nil, err
+	}
+	password, err := config.GetString("postgres.password")
+	if err != nil {
+		return nil, err
+	}
+
+	return persistence.NewPostgresRepository(host, port, user, password)
+}
+
 func responseCacheHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", "public, max-age=604800, immutable")